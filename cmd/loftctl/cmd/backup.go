@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
 	"github.com/loft-sh/api/v4/pkg/product"
@@ -10,9 +15,11 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/backup"
 	loftclient "github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/clihelper"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
 	"github.com/loft-sh/log/survey"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -34,8 +41,30 @@ type BackupCmd struct {
 	Namespace string
 	Filename  string
 	Skip      []string
+	Only      []string
+	Projects  []string
+	Selector  string
+	FromAPI   bool
+	Format    string
+
+	Concurrency int
+
+	Destination string
+	Timestamp   bool
+	Keep        int
+
+	Encrypt        string
+	PassphraseFile string
+	Recipient      string
 }
 
+const (
+	backupPassphraseEnv = "LOFT_BACKUP_PASSPHRASE"
+
+	backupFormatYAML    = "yaml"
+	backupFormatArchive = "archive"
+)
+
 // NewBackupCmd creates a new command
 func NewBackupCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
 	cmd := &BackupCmd{
@@ -48,6 +77,11 @@ Backup creates a backup for the Loft management plane
 
 Example:
 loft backup
+loft backup --from-api
+loft backup --format archive
+loft backup --project my-project --selector team=frontend
+loft backup --encrypt secrets --passphrase-file passphrase.txt
+loft backup --destination s3://my-bucket/loft --timestamp --keep 7
 ########################################################
 	`)
 
@@ -67,66 +101,292 @@ loft backup
 		},
 	}
 
-	c.Flags().StringSliceVar(&cmd.Skip, "skip", []string{}, "What resources the backup should skip. Valid options are: users, teams, accesskeys, sharedsecrets, clusters and clusteraccounttemplates")
+	c.AddCommand(NewBackupVerifyCmd(globalFlags))
+	c.AddCommand(NewBackupDiffCmd(globalFlags))
+	c.Flags().StringSliceVar(&cmd.Skip, "skip", []string{}, "What resources the backup should skip. Valid options are: clusterroletemplates, clusteraccesses, spaceconstraints, users, teams, sharedsecrets, accesskeys, apps, spacetemplates, virtualclustertemplates, devpodworkspacetemplates, clusters, runners, projects, virtualclusterinstances, devpodworkspaceinstances, spaceinstances and projectsecrets")
+	c.Flags().StringSliceVar(&cmd.Only, "only", []string{}, "If set, only the given resources are backed up. Uses the same names as --skip")
+	c.Flags().StringSliceVar(&cmd.Projects, "project", []string{}, "If set, only the given projects are backed up together with their instances, project secrets and the templates the instances reference")
+	c.Flags().StringVarP(&cmd.Selector, "selector", "l", "", "If set, only objects matching the label selector are backed up")
 	c.Flags().StringVar(&cmd.Namespace, "namespace", "loft", product.Replace("The namespace to loft was installed into"))
-	c.Flags().StringVar(&cmd.Filename, "filename", "backup.yaml", "The filename to write the backup to")
+	c.Flags().StringVar(&cmd.Filename, "filename", "backup.yaml", "The filename to write the backup to. Defaults to backup.tar.gz for the archive format")
+	c.Flags().StringVar(&cmd.Destination, "destination", "", "Where to store the backup. Either a local directory, - for stdout or an S3 compatible bucket (s3://bucket/prefix?endpoint=https://minio:9000&path-style=true). S3 credentials are read from AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY. Defaults to the directory of --filename")
+	c.Flags().BoolVar(&cmd.Timestamp, "timestamp", false, "If enabled, the current time is added to the backup name, e.g. backup-20240101T000000Z.yaml")
+	c.Flags().IntVar(&cmd.Keep, "keep", 0, "If set, only the newest N timestamped backups are kept in the destination. Requires --timestamp")
+	c.Flags().IntVar(&cmd.Concurrency, "concurrency", backup.DefaultConcurrency, "The maximum number of resources that are backed up in parallel")
+	c.Flags().StringVar(&cmd.Format, "format", backupFormatYAML, "The format of the backup. Valid options are: yaml (a single multi document file) and archive (a tar.gz with one file per object and a manifest)")
+	c.Flags().BoolVar(&cmd.FromAPI, "from-api", false, product.Replace("If enabled, the backup is gathered through the loft api with the current login instead of the host cluster kube config. Requires an admin access key. Access keys and secrets that are only stored in the host cluster are not backed up this way"))
+	c.Flags().StringVar(&cmd.Encrypt, "encrypt", "", "Encrypt the backup. Valid options are: secrets (only the values of secrets are encrypted) and file (the whole backup is encrypted)")
+	c.Flags().StringVar(&cmd.PassphraseFile, "passphrase-file", "", "The file to read the encryption passphrase from. Can also be set via the "+backupPassphraseEnv+" environment variable")
+	c.Flags().StringVar(&cmd.Recipient, "recipient", "", "Path to a PEM encoded RSA public key or certificate to encrypt the backup for instead of using a passphrase")
 	return c
 }
 
 // Run executes the functionality
 func (cmd *BackupCmd) Run(cobraCmd *cobra.Command, args []string) error {
-	// first load the kube config
-	kubeClientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+	if cmd.Format != backupFormatYAML && cmd.Format != backupFormatArchive {
+		return fmt.Errorf("unrecognized backup format %s, needs to be either yaml or archive", cmd.Format)
+	} else if cmd.Format == backupFormatArchive && !cobraCmd.Flags().Changed("filename") {
+		cmd.Filename = "backup.tar.gz"
+	}
 
-	// load the raw config
-	kubeConfig, err := kubeClientConfig.ClientConfig()
+	destination, name, err := cmd.newDestination()
 	if err != nil {
-		return fmt.Errorf("there is an error loading your current kube config (%w), please make sure you have access to a kubernetes cluster and the command `kubectl get namespaces` is working", err)
+		return err
+	} else if _, ok := destination.(*backup.StdoutDestination); ok {
+		// keep stdout clean for the backup
+		cmd.Log = cmd.Log.ErrorStreamOnly()
 	}
 
-	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	encrypter, err := cmd.newEncrypter()
 	if err != nil {
-		return fmt.Errorf("there is an error loading your current kube config (%w), please make sure you have access to a kubernetes cluster and the command `kubectl get namespaces` is working", err)
+		return err
 	}
 
-	isInstalled, err := clihelper.IsLoftAlreadyInstalled(cobraCmd.Context(), kubeClient, cmd.Namespace)
+	objects, errors, err := cmd.collect(cobraCmd.Context())
+	if err != nil || objects == nil {
+		return err
+	}
+	for _, err := range errors {
+		cmd.Log.Warn(err)
+	}
+	if encrypter != nil && cmd.Encrypt == backup.EncryptionScopeSecrets {
+		err = encrypter.EncryptSecrets(objects)
+		if err != nil {
+			return err
+		}
+	}
+	backupBytes, err := cmd.marshal(objects)
 	if err != nil {
 		return err
-	} else if !isInstalled {
-		answer, err := cmd.Log.Question(&survey.QuestionOptions{
-			Question:     fmt.Sprintf(product.Replace("Seems like Loft was not installed into namespace %q, do you want to continue?"), cmd.Namespace),
-			DefaultValue: "Yes",
-			Options:      []string{"Yes", "No"},
-		})
-		if err != nil || answer != "Yes" {
+	}
+	if encrypter != nil && cmd.Encrypt == backup.EncryptionScopeFile {
+		backupBytes, err = encrypter.EncryptFile(backupBytes)
+		if err != nil {
 			return err
 		}
 	}
 
 	ctx := cobraCmd.Context()
-	client, err := clientpkg.New(kubeConfig, clientpkg.Options{Scheme: scheme})
+	cmd.Log.Infof("Writing backup %s to %s...", name, destination)
+	err = destination.Write(ctx, name, backupBytes)
 	if err != nil {
-		return err
+		return fmt.Errorf("write backup: %w", err)
 	}
 
-	objects, errors := backup.All(ctx, client, cmd.Skip, func(msg string) {
-		cmd.Log.Info(msg)
-	})
-	for _, err := range errors {
-		cmd.Log.Warn(err)
+	deleted, err := backup.ApplyRetention(ctx, destination, filepath.Base(cmd.Filename), cmd.Keep)
+	for _, d := range deleted {
+		cmd.Log.Infof("Deleted old backup %s", d)
 	}
-	backupBytes, err := backup.ToYAML(objects)
 	if err != nil {
-		return err
+		return fmt.Errorf("apply retention: %w", err)
+	}
+
+	cmd.Log.Donef("Wrote backup %s to %s", name, destination)
+	return nil
+}
+
+// newDestination parses the destination and returns the name the backup should be stored with
+func (cmd *BackupCmd) newDestination() (backup.Destination, string, error) {
+	if cmd.Keep < 0 {
+		return nil, "", fmt.Errorf("--keep needs to be 0 or greater")
+	} else if cmd.Keep > 0 && !cmd.Timestamp {
+		return nil, "", fmt.Errorf("--keep requires --timestamp, as only timestamped backups are cleaned up")
+	}
+
+	name := filepath.Base(cmd.Filename)
+	if cmd.Timestamp {
+		name = backup.TimestampedName(name, time.Now())
 	}
 
-	// create a file
-	cmd.Log.Infof("Writing backup to %s...", cmd.Filename)
-	err = os.WriteFile(cmd.Filename, backupBytes, 0644)
+	if cmd.Destination == "" {
+		return &backup.FileDestination{Dir: filepath.Dir(cmd.Filename)}, name, nil
+	}
+
+	destination, err := backup.NewDestination(cmd.Destination)
 	if err != nil {
-		return err
+		return nil, "", err
+	} else if _, ok := destination.(*backup.StdoutDestination); ok && cmd.Keep > 0 {
+		return nil, "", fmt.Errorf("--keep is not supported when writing the backup to stdout")
 	}
 
-	cmd.Log.Donef("Wrote backup to %s", cmd.Filename)
-	return nil
+	return destination, name, nil
+}
+
+func (cmd *BackupCmd) marshal(objects []runtime.Object) ([]byte, error) {
+	if cmd.Format != backupFormatArchive {
+		return backup.ToYAML(objects)
+	}
+
+	manifest := backup.Manifest{
+		CLIVersion: upgrade.GetVersion(),
+		Created:    time.Now().UTC(),
+		Skipped:    cmd.skipped(),
+		Only:       cmd.Only,
+		Projects:   cmd.Projects,
+		Selector:   cmd.Selector,
+	}
+	loftVersion, err := cmd.loftVersion()
+	if err != nil {
+		cmd.Log.Warnf("Error retrieving %s version for the backup manifest: %v", product.DisplayName(), err)
+	}
+	manifest.LoftVersion = loftVersion
+
+	return backup.ToArchive(objects, manifest)
+}
+
+// skipped returns the resources that are not part of the backup, because they were either
+// skipped by the user or can't be backed up through the management api
+func (cmd *BackupCmd) skipped() []string {
+	skipped := append([]string{}, cmd.Skip...)
+	if cmd.FromAPI {
+		for _, name := range backup.ManagementSkipped {
+			if !slices.Contains(skipped, name) {
+				skipped = append(skipped, name)
+			}
+		}
+	}
+
+	return skipped
+}
+
+func (cmd *BackupCmd) loftVersion() (string, error) {
+	baseClient, err := loftclient.NewClientFromPath(cmd.Config)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := baseClient.Version()
+	if err != nil {
+		return "", err
+	}
+
+	return version.Version, nil
+}
+
+// collect gathers the objects to back up either from the host cluster or through the management api.
+// Returns nil objects if the user decided to not continue.
+func (cmd *BackupCmd) collect(ctx context.Context) ([]runtime.Object, []error, error) {
+	selector, err := labels.Parse(cmd.Selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse selector: %w", err)
+	}
+
+	options := backup.Options{
+		Skip:        cmd.Skip,
+		Only:        cmd.Only,
+		Projects:    cmd.Projects,
+		Selector:    selector,
+		Concurrency: cmd.Concurrency,
+		Progress: backup.LogProgress(func(msg string) {
+			cmd.Log.Info(msg)
+		}),
+	}
+
+	if cmd.FromAPI {
+		baseClient, err := loftclient.NewClientFromPath(cmd.Config)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		managementClient, err := baseClient.Management()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		objects, errors := backup.AllFromManagement(ctx, managementClient, scheme, options)
+		return objects, errors, nil
+	}
+
+	client, err := newHostClusterClient(ctx, cmd.Namespace, cmd.Log)
+	if err != nil || client == nil {
+		return nil, nil, err
+	}
+
+	objects, errors := backup.All(ctx, client, options)
+	return objects, errors, nil
+}
+
+func (cmd *BackupCmd) newEncrypter() (*backup.Encrypter, error) {
+	if cmd.Encrypt == "" {
+		return nil, nil
+	} else if cmd.Encrypt != backup.EncryptionScopeSecrets && cmd.Encrypt != backup.EncryptionScopeFile {
+		return nil, fmt.Errorf("unrecognized encryption mode %s, needs to be either secrets or file", cmd.Encrypt)
+	}
+
+	var err error
+	key := backup.EncryptionKey{}
+	if cmd.Recipient != "" {
+		key.PublicKey, err = backup.LoadPublicKey(cmd.Recipient)
+	} else {
+		key.Passphrase, err = backupPassphrase(cmd.PassphraseFile, cmd.Log)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return backup.NewEncrypter(key)
+}
+
+// backupPassphrase reads the backup passphrase from the given file or the environment
+// and asks the user for it if neither is set
+func backupPassphrase(passphraseFile string, log log.Logger) (string, error) {
+	passphrase := os.Getenv(backupPassphraseEnv)
+	if passphraseFile != "" {
+		out, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase file: %w", err)
+		}
+
+		passphrase = strings.TrimSpace(string(out))
+	} else if passphrase == "" {
+		answer, err := log.Question(&survey.QuestionOptions{
+			Question:   "Please enter the backup passphrase",
+			IsPassword: true,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		passphrase = answer
+	}
+
+	if passphrase == "" {
+		return "", fmt.Errorf("backup passphrase is empty")
+	}
+
+	return passphrase, nil
+}
+
+// newHostClusterClient creates a client for the cluster loft is installed in from the
+// current kube config. Returns a nil client if the user decided to not continue.
+func newHostClusterClient(ctx context.Context, namespace string, log log.Logger) (clientpkg.Client, error) {
+	// first load the kube config
+	kubeClientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+
+	// load the raw config
+	kubeConfig, err := kubeClientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("there is an error loading your current kube config (%w), please make sure you have access to a kubernetes cluster and the command `kubectl get namespaces` is working", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("there is an error loading your current kube config (%w), please make sure you have access to a kubernetes cluster and the command `kubectl get namespaces` is working", err)
+	}
+
+	isInstalled, err := clihelper.IsLoftAlreadyInstalled(ctx, kubeClient, namespace)
+	if err != nil {
+		return nil, err
+	} else if !isInstalled {
+		answer, err := log.Question(&survey.QuestionOptions{
+			Question:     fmt.Sprintf(product.Replace("Seems like Loft was not installed into namespace %q, do you want to continue?"), namespace),
+			DefaultValue: "Yes",
+			Options:      []string{"Yes", "No"},
+		})
+		if err != nil || answer != "Yes" {
+			return nil, err
+		}
+	}
+
+	return clientpkg.New(kubeConfig, clientpkg.Options{Scheme: scheme})
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/backup"
+	"github.com/loft-sh/log"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// BackupDiffCmd holds the cmd flags
+type BackupDiffCmd struct {
+	*flags.GlobalFlags
+	Log log.Logger
+
+	PassphraseFile string
+	PrivateKey     string
+}
+
+// NewBackupDiffCmd creates a new command
+func NewBackupDiffCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &BackupDiffCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	description := product.ReplaceWithHeader("backup diff", `
+Diff compares two backups and shows the added, removed
+and changed objects. Secret values are masked.
+
+Example:
+loft backup diff old.yaml new.yaml
+########################################################
+	`)
+
+	c := &cobra.Command{
+		Use:   "diff [old] [new]",
+		Short: "Show the differences between two backups",
+		Long:  description,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(args[0], args[1])
+		},
+	}
+
+	c.Flags().StringVar(&cmd.PassphraseFile, "passphrase-file", "", "The file to read the passphrase of encrypted backups from. Can also be set via the "+backupPassphraseEnv+" environment variable")
+	c.Flags().StringVar(&cmd.PrivateKey, "private-key", "", "Path to a PEM encoded RSA private key to decrypt backups that were encrypted for a public key")
+	return c
+}
+
+// Run executes the functionality
+func (cmd *BackupDiffCmd) Run(oldFilename, newFilename string) error {
+	reader := &backupReader{
+		PassphraseFile: cmd.PassphraseFile,
+		PrivateKey:     cmd.PrivateKey,
+		Log:            cmd.Log,
+	}
+	oldObjects, _, err := reader.Read(oldFilename)
+	if err != nil {
+		return err
+	}
+	newObjects, _, err := reader.Read(newFilename)
+	if err != nil {
+		return err
+	}
+
+	diffs, err := backup.Diff(scheme, oldObjects, newObjects)
+	if err != nil {
+		return err
+	} else if len(diffs) == 0 {
+		cmd.Log.Infof("No differences found between %s and %s", oldFilename, newFilename)
+		return nil
+	}
+
+	out := &strings.Builder{}
+	added, removed, changed := 0, 0, 0
+	for _, diff := range diffs {
+		switch diff.Type {
+		case backup.DiffTypeAdded:
+			added++
+			fmt.Fprintf(out, "+ %s\n", diff.DisplayName())
+		case backup.DiffTypeRemoved:
+			removed++
+			fmt.Fprintf(out, "- %s\n", diff.DisplayName())
+		case backup.DiffTypeChanged:
+			changed++
+			fmt.Fprintf(out, "~ %s\n", diff.DisplayName())
+			for _, field := range diff.Fields {
+				fmt.Fprintf(out, "    %s: %s -> %s\n", field.Path, fieldValue(field.Old), fieldValue(field.New))
+			}
+		}
+	}
+	cmd.Log.WriteString(logrus.InfoLevel, out.String())
+
+	cmd.Log.Infof("%d added, %d removed, %d changed", added, removed, changed)
+	return nil
+}
+
+func fieldValue(value string) string {
+	if value == "" {
+		return "<unset>"
+	}
+
+	return value
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/backup"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+)
+
+// BackupVerifyCmd holds the cmd flags
+type BackupVerifyCmd struct {
+	*flags.GlobalFlags
+	Log log.Logger
+
+	PassphraseFile string
+	PrivateKey     string
+}
+
+// NewBackupVerifyCmd creates a new command
+func NewBackupVerifyCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &BackupVerifyCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	description := product.ReplaceWithHeader("backup verify", `
+Verify checks the files of an archive backup against
+the checksums in its manifest
+
+Example:
+loft backup verify backup.tar.gz
+########################################################
+	`)
+
+	c := &cobra.Command{
+		Use:   "verify [filename]",
+		Short: "Verify an archive backup",
+		Long:  description,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(args[0])
+		},
+	}
+
+	c.Flags().StringVar(&cmd.PassphraseFile, "passphrase-file", "", "The file to read the passphrase of an encrypted backup from. Can also be set via the "+backupPassphraseEnv+" environment variable")
+	c.Flags().StringVar(&cmd.PrivateKey, "private-key", "", "Path to a PEM encoded RSA private key to decrypt a backup that was encrypted for a public key")
+	return c
+}
+
+// Run executes the functionality
+func (cmd *BackupVerifyCmd) Run(filename string) error {
+	backupBytes, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	if backup.IsEncryptedFile(backupBytes) {
+		decrypter, err := newBackupDecrypter(cmd.PassphraseFile, cmd.PrivateKey, cmd.Log)
+		if err != nil {
+			return err
+		}
+
+		backupBytes, err = decrypter.DecryptFile(backupBytes)
+		if err != nil {
+			return fmt.Errorf("decrypt backup %s: %w", filename, err)
+		}
+	}
+	if !backup.IsArchive(backupBytes) {
+		return fmt.Errorf("%s is not an archive backup, only backups created with --format archive can be verified", filename)
+	}
+
+	manifest, errors := backup.VerifyArchive(backupBytes)
+	for _, err := range errors {
+		cmd.Log.Warn(err)
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("backup %s is invalid: %d error(s) found", filename, len(errors))
+	}
+
+	cmd.Log.Infof("Created: %s", manifest.Created.Format(time.RFC3339))
+	cmd.Log.Infof("CLI version: %s", manifest.CLIVersion)
+	cmd.Log.Infof("%s version: %s", product.DisplayName(), manifest.LoftVersion)
+	if len(manifest.Skipped) > 0 {
+		cmd.Log.Infof("Skipped resources: %v", manifest.Skipped)
+	}
+	if len(manifest.Only) > 0 {
+		cmd.Log.Infof("Limited to resources: %v", manifest.Only)
+	}
+	if len(manifest.Projects) > 0 {
+		cmd.Log.Infof("Limited to projects: %v", manifest.Projects)
+	}
+	if manifest.Selector != "" {
+		cmd.Log.Infof("Label selector: %s", manifest.Selector)
+	}
+	cmd.Log.Donef("Verified %d file(s) in backup %s", len(manifest.Files), filename)
+	return nil
+}
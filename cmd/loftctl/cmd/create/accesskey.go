@@ -0,0 +1,219 @@
+package create
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	managementv1 "github.com/loft-sh/api/v4/pkg/apis/management/v1"
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/random"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/log"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessKeyCmd holds the cmd flags
+type AccessKeyCmd struct {
+	*flags.GlobalFlags
+
+	DisplayName          string
+	Description          string
+	TTL                  time.Duration
+	TTLAfterLastActivity bool
+	Team                 string
+
+	ScopeProjects        []string
+	ScopeSpaces          []string
+	ScopeVirtualClusters []string
+	ScopeClusters        []string
+
+	Log log.Logger
+}
+
+// NewAccessKeyCmd creates a new command
+func NewAccessKeyCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &AccessKeyCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("create accesskey", `
+Creates a new access key for the current user or one of
+their teams. The key is only printed once.
+
+Example:
+loft create accesskey
+loft create accesskey ci --ttl 720h
+loft create accesskey ci --team my-team --scope-project my-project
+TOKEN=$(loft create accesskey ci --silent)
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+########################################################
+############ devspace create accesskey #################
+########################################################
+Creates a new access key for the current user or one of
+their teams. The key is only printed once.
+
+Example:
+devspace create accesskey
+devspace create accesskey ci --ttl 720h
+devspace create accesskey ci --team my-team --scope-project my-project
+########################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "accesskey [name]",
+		Short: "Creates a new access key",
+		Long:  description,
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	c.Flags().StringVar(&cmd.DisplayName, "display-name", "", "The display name of the access key")
+	c.Flags().StringVar(&cmd.Description, "description", "", "The description of the access key")
+	c.Flags().DurationVar(&cmd.TTL, "ttl", 0, "The time the access key is valid, e.g. 720h. If 0, the access key never expires")
+	c.Flags().BoolVar(&cmd.TTLAfterLastActivity, "ttl-after-last-activity", false, "If enabled, the ttl is counted from the last time the access key was used instead of its creation")
+	c.Flags().StringVar(&cmd.Team, "team", "", "The team to create the access key for. If omitted, the access key is created for the current user")
+	c.Flags().StringSliceVar(&cmd.ScopeProjects, "scope-project", []string{}, "Restricts the access key to the given project")
+	c.Flags().StringSliceVar(&cmd.ScopeSpaces, "scope-space", []string{}, "Restricts the access key to the given space in the form project/space")
+	c.Flags().StringSliceVar(&cmd.ScopeVirtualClusters, "scope-vcluster", []string{}, "Restricts the access key to the given virtual cluster in the form project/vcluster")
+	c.Flags().StringSliceVar(&cmd.ScopeClusters, "scope-cluster", []string{}, "Restricts the access key to the given cluster")
+	return c
+}
+
+// Run executes the command
+func (cmd *AccessKeyCmd) Run(ctx context.Context, args []string) error {
+	if cmd.TTL < 0 {
+		return fmt.Errorf("--ttl cannot be negative")
+	} else if cmd.TTLAfterLastActivity && cmd.TTL == 0 {
+		return fmt.Errorf("--ttl-after-last-activity requires --ttl")
+	}
+
+	scope, err := cmd.scope()
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	name := "accesskey-" + random.RandomString(8)
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	key, err := generateAccessKey()
+	if err != nil {
+		return err
+	}
+
+	accessKey := &managementv1.OwnedAccessKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: managementv1.OwnedAccessKeySpec{
+			AccessKeySpec: storagev1.AccessKeySpec{
+				DisplayName:          cmd.DisplayName,
+				Description:          cmd.Description,
+				Key:                  key,
+				Type:                 storagev1.AccessKeyTypeUser,
+				TTL:                  int64(cmd.TTL.Seconds()),
+				TTLAfterLastActivity: cmd.TTLAfterLastActivity,
+				Scope:                scope,
+			},
+		},
+	}
+	if cmd.Team != "" {
+		accessKey.Spec.Team = cmd.Team
+	} else if self := baseClient.Self(); self.Status.User != nil {
+		accessKey.Spec.User = self.Status.User.Name
+	} else if self.Status.Team != nil {
+		accessKey.Spec.Team = self.Status.Team.Name
+	}
+
+	accessKey, err = managementClient.Loft().ManagementV1().OwnedAccessKeys().Create(ctx, accessKey, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "create access key")
+	}
+	if accessKey.Spec.Key != "" {
+		key = accessKey.Spec.Key
+	}
+
+	cmd.Log.Donef("Successfully created access key %s. Please store it now, it cannot be retrieved again:", ansi.Color(accessKey.Name, "white+b"))
+	_, err = os.Stdout.Write([]byte(key + "\n"))
+	return err
+}
+
+// scope builds the access key scope from the scope flags, nil if the access key is not restricted
+func (cmd *AccessKeyCmd) scope() (*storagev1.AccessKeyScope, error) {
+	scope := &storagev1.AccessKeyScope{}
+	for _, project := range cmd.ScopeProjects {
+		scope.Projects = append(scope.Projects, storagev1.AccessKeyScopeProject{Project: project})
+	}
+	for _, space := range cmd.ScopeSpaces {
+		project, name, err := splitProjectName(space, "--scope-space")
+		if err != nil {
+			return nil, err
+		}
+
+		scope.Spaces = append(scope.Spaces, storagev1.AccessKeyScopeSpace{Project: project, Space: name})
+	}
+	for _, virtualCluster := range cmd.ScopeVirtualClusters {
+		project, name, err := splitProjectName(virtualCluster, "--scope-vcluster")
+		if err != nil {
+			return nil, err
+		}
+
+		scope.VirtualClusters = append(scope.VirtualClusters, storagev1.AccessKeyScopeVirtualCluster{Project: project, VirtualCluster: name})
+	}
+	for _, cluster := range cmd.ScopeClusters {
+		scope.Clusters = append(scope.Clusters, storagev1.AccessKeyScopeCluster{Cluster: cluster})
+	}
+
+	if len(scope.Projects) == 0 && len(scope.Spaces) == 0 && len(scope.VirtualClusters) == 0 && len(scope.Clusters) == 0 {
+		return nil, nil
+	}
+
+	return scope, nil
+}
+
+func splitProjectName(value, flag string) (string, string, error) {
+	project, name, found := strings.Cut(value, "/")
+	if !found || project == "" || name == "" {
+		return "", "", fmt.Errorf("invalid %s %s, needs to be in the form project/name", flag, value)
+	}
+
+	return project, name, nil
+}
+
+// generateAccessKey returns a random 64 character key
+func generateAccessKey() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", errors.Wrap(err, "generate access key")
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,34 @@
+package create
+
+import (
+	"testing"
+
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestAccessKeyScope(t *testing.T) {
+	cmd := &AccessKeyCmd{}
+	scope, err := cmd.scope()
+	assert.NilError(t, err)
+	assert.Assert(t, scope == nil)
+
+	cmd = &AccessKeyCmd{
+		ScopeProjects:        []string{"a"},
+		ScopeSpaces:          []string{"a/space"},
+		ScopeVirtualClusters: []string{"b/vcluster"},
+		ScopeClusters:        []string{"loft-cluster"},
+	}
+	scope, err = cmd.scope()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, scope, &storagev1.AccessKeyScope{
+		Projects:        []storagev1.AccessKeyScopeProject{{Project: "a"}},
+		Spaces:          []storagev1.AccessKeyScopeSpace{{Project: "a", Space: "space"}},
+		VirtualClusters: []storagev1.AccessKeyScopeVirtualCluster{{Project: "b", VirtualCluster: "vcluster"}},
+		Clusters:        []storagev1.AccessKeyScopeCluster{{Cluster: "loft-cluster"}},
+	})
+
+	cmd = &AccessKeyCmd{ScopeSpaces: []string{"space"}}
+	_, err = cmd.scope()
+	assert.ErrorContains(t, err, "needs to be in the form project/name")
+}
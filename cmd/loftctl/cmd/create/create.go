@@ -26,5 +26,6 @@ func NewCreateCmd(globalFlags *flags.GlobalFlags, defaults *pdefaults.Defaults)
 	}
 	c.AddCommand(NewSpaceCmd(globalFlags, defaults))
 	c.AddCommand(NewVirtualClusterCmd(globalFlags, defaults))
+	c.AddCommand(NewAccessKeyCmd(globalFlags))
 	return c
 }
@@ -0,0 +1,97 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/log"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AccessKeyCmd holds the cmd flags
+type AccessKeyCmd struct {
+	*flags.GlobalFlags
+
+	Force bool
+
+	Log log.Logger
+}
+
+// NewAccessKeyCmd creates a new command
+func NewAccessKeyCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &AccessKeyCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("delete accesskey", `
+Deletes one or more access keys of the current user
+
+Example:
+loft delete accesskey my-key
+loft delete accesskey my-key other-key
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+#######################################################
+############# devspace delete accesskey ###############
+#######################################################
+Deletes one or more access keys of the current user
+
+Example:
+devspace delete accesskey my-key
+devspace delete accesskey my-key other-key
+#######################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "accesskey [name]...",
+		Short: "Deletes access keys of the current user",
+		Long:  description,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	c.Flags().BoolVar(&cmd.Force, "force", false, "If enabled, the access key this cli is logged in with can be deleted as well")
+	return c
+}
+
+// Run executes the command
+func (cmd *AccessKeyCmd) Run(ctx context.Context, args []string) error {
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	currentAccessKey := baseClient.Self().Status.AccessKey
+	for _, name := range args {
+		if name == currentAccessKey && !cmd.Force {
+			return fmt.Errorf("access key %s is used by this cli, deleting it would log you out. Use --force to delete it anyway", name)
+		}
+	}
+
+	for _, name := range args {
+		err = managementClient.Loft().ManagementV1().OwnedAccessKeys().Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "delete access key %s", name)
+		}
+
+		cmd.Log.Donef("Successfully deleted access key %s", ansi.Color(name, "white+b"))
+	}
+
+	return nil
+}
@@ -27,5 +27,6 @@ func NewDeleteCmd(globalFlags *flags.GlobalFlags, defaults *defaults.Defaults) *
 
 	c.AddCommand(NewSpaceCmd(globalFlags, defaults))
 	c.AddCommand(NewVirtualClusterCmd(globalFlags, defaults))
+	c.AddCommand(NewAccessKeyCmd(globalFlags))
 	return c
 }
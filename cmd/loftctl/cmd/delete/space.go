@@ -10,6 +10,7 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/util"
 
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/bulk"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	"github.com/loft-sh/loftctl/v4/pkg/kube"
@@ -30,6 +31,8 @@ type SpaceCmd struct {
 	Project       string
 	DeleteContext bool
 	Wait          bool
+	Bulk          bulk.Flags
+	Yes           bool
 
 	Log log.Logger
 }
@@ -46,6 +49,8 @@ Deletes a space from a cluster
 Example:
 loft delete space myspace
 loft delete space myspace --project myproject
+loft delete space preview-1 preview-2 --project myproject
+loft delete space --project myproject --selector env=preview
 ########################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -58,14 +63,16 @@ Deletes a space from a cluster
 Example:
 devspace delete space myspace
 devspace delete space myspace --project myproject
+devspace delete space preview-1 preview-2 --project myproject
+devspace delete space --project myproject --selector env=preview
 #######################################################
 	`
 	}
 	c := &cobra.Command{
-		Use:   "space" + util.SpaceNameOnlyUseLine,
+		Use:   "space" + util.SpaceNamesUseLine,
 		Short: "Deletes a space from a cluster",
 		Long:  description,
-		Args:  util.SpaceNameOnlyValidator,
+		Args:  util.SpaceNamesValidator,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			// Check for newer version
 			upgrade.PrintNewerVersionWarning()
@@ -79,6 +86,8 @@ devspace delete space myspace --project myproject
 	c.Flags().StringVarP(&cmd.Project, "project", "p", p, "The project to use")
 	c.Flags().BoolVar(&cmd.DeleteContext, "delete-context", true, "If the corresponding kube context should be deleted if there is any")
 	c.Flags().BoolVar(&cmd.Wait, "wait", false, "Termination of this command waits for space to be deleted")
+	cmd.Bulk.AddFlags(c.Flags(), "spaces")
+	c.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "If true, deletes several spaces without asking for confirmation")
 	return c
 }
 
@@ -89,6 +98,25 @@ func (cmd *SpaceCmd) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if cmd.Bulk.Enabled(args) {
+		names, err := cmd.Bulk.Names(cmd.Project, args, bulk.SpaceInstanceNames(ctx, baseClient, cmd.Project))
+		if err != nil {
+			return err
+		}
+
+		confirmed, err := bulk.Confirm("delete", names, cmd.Yes, cmd.Log)
+		if err != nil {
+			return err
+		} else if !confirmed {
+			cmd.Log.Info("Nothing was deleted")
+			return nil
+		}
+
+		return bulk.Run(ctx, "delete", names, func(ctx context.Context, name string) error {
+			return cmd.deleteSpace(ctx, baseClient, name)
+		}, cmd.Log)
+	}
+
 	spaceName := ""
 	if len(args) > 0 {
 		spaceName = args[0]
@@ -131,11 +159,11 @@ func (cmd *SpaceCmd) deleteSpace(ctx context.Context, baseClient client.Client,
 
 	// wait until deleted
 	if cmd.Wait {
-		cmd.Log.Info("Waiting for space to be deleted...")
+		cmd.Log.Infof("Waiting for space %s to be deleted...", spaceName)
 		for isSpaceInstanceStillThere(ctx, managementClient, projectutil.ProjectNamespace(cmd.Project), spaceName) {
 			time.Sleep(time.Second)
 		}
-		cmd.Log.Done("Space is deleted")
+		cmd.Log.Donef("Space %s is deleted", spaceName)
 	}
 
 	return nil
@@ -11,6 +11,7 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/util"
 
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/bulk"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	pdefaults "github.com/loft-sh/loftctl/v4/pkg/defaults"
@@ -33,6 +34,8 @@ type VirtualClusterCmd struct {
 	DeleteContext bool
 	DeleteSpace   bool
 	Wait          bool
+	Bulk          bulk.Flags
+	Yes           bool
 
 	Log log.Logger
 }
@@ -49,6 +52,8 @@ Deletes a virtual cluster from a cluster
 Example:
 loft delete vcluster myvirtualcluster
 loft delete vcluster myvirtualcluster --project myproject
+loft delete vcluster preview-1 preview-2 --project myproject
+loft delete vcluster --project myproject --selector env=preview
 ########################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -61,14 +66,16 @@ Deletes a virtual cluster from a cluster
 Example:
 devspace delete vcluster myvirtualcluster
 devspace delete vcluster myvirtualcluster --project myproject
+devspace delete vcluster preview-1 preview-2 --project myproject
+devspace delete vcluster --project myproject --selector env=preview
 #######################################################
 	`
 	}
 	c := &cobra.Command{
-		Use:   "vcluster" + util.VClusterNameOnlyUseLine,
+		Use:   "vcluster" + util.VClusterNamesUseLine,
 		Short: "Deletes a virtual cluster from a cluster",
 		Long:  description,
-		Args:  util.VClusterNameOnlyValidator,
+		Args:  util.VClusterNamesValidator,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			// Check for newer version
 			upgrade.PrintNewerVersionWarning()
@@ -84,6 +91,8 @@ devspace delete vcluster myvirtualcluster --project myproject
 	c.Flags().BoolVar(&cmd.DeleteContext, "delete-context", true, "If the corresponding kube context should be deleted if there is any")
 	c.Flags().BoolVar(&cmd.DeleteSpace, "delete-space", false, "Should the corresponding space be deleted")
 	c.Flags().BoolVar(&cmd.Wait, "wait", false, "Termination of this command waits for space to be deleted. Without the flag delete-space, this flag has no effect.")
+	cmd.Bulk.AddFlags(c.Flags(), "vclusters")
+	c.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "If true, deletes several vclusters without asking for confirmation")
 	return c
 }
 
@@ -94,6 +103,25 @@ func (cmd *VirtualClusterCmd) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if cmd.Bulk.Enabled(args) {
+		names, err := cmd.Bulk.Names(cmd.Project, args, bulk.VirtualClusterInstanceNames(ctx, baseClient, cmd.Project))
+		if err != nil {
+			return err
+		}
+
+		confirmed, err := bulk.Confirm("delete", names, cmd.Yes, cmd.Log)
+		if err != nil {
+			return err
+		} else if !confirmed {
+			cmd.Log.Info("Nothing was deleted")
+			return nil
+		}
+
+		return bulk.Run(ctx, "delete", names, func(ctx context.Context, name string) error {
+			return cmd.deleteVirtualCluster(ctx, baseClient, name)
+		}, cmd.Log)
+	}
+
 	virtualClusterName := ""
 	if len(args) > 0 {
 		virtualClusterName = args[0]
@@ -136,11 +164,11 @@ func (cmd *VirtualClusterCmd) deleteVirtualCluster(ctx context.Context, baseClie
 
 	// wait until deleted
 	if cmd.Wait {
-		cmd.Log.Info("Waiting for virtual cluster to be deleted...")
+		cmd.Log.Infof("Waiting for virtual cluster %s to be deleted...", virtualClusterName)
 		for isVirtualClusterInstanceStillThere(ctx, managementClient, projectutil.ProjectNamespace(cmd.Project), virtualClusterName) {
 			time.Sleep(time.Second)
 		}
-		cmd.Log.Done("Virtual Cluster is deleted")
+		cmd.Log.Donef("Virtual cluster %s is deleted", virtualClusterName)
 	}
 
 	return nil
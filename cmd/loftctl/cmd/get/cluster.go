@@ -2,17 +2,16 @@ package get
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
-	"github.com/ghodss/yaml"
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
 	"github.com/loft-sh/loftctl/v4/pkg/util"
 	"github.com/loft-sh/log"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -50,7 +49,7 @@ loft get cluster-access-key [CLUSTER_NAME]
 		},
 	}
 
-	c.Flags().StringVarP(&cmd.Output, "output", "o", "text", "Output format. One of: (json, yaml, text)")
+	printer.AddFlag(c.Flags(), &cmd.Output)
 
 	return c
 }
@@ -58,6 +57,11 @@ loft get cluster-access-key [CLUSTER_NAME]
 func (cmd *ClusterTokenCmd) Run(ctx context.Context, args []string) error {
 	clusterName := args[0]
 
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
 	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
 	if err != nil {
 		return fmt.Errorf("new client from path: %w", err)
@@ -82,33 +86,20 @@ func (cmd *ClusterTokenCmd) Run(ctx context.Context, args []string) error {
 		CreationTimestamp: metav1.NewTime(time.Now()),
 	}
 
-	switch cmd.Output {
-	case OutputJSON:
-		serialized, err := json.Marshal(accessKey)
-		if err != nil {
-			return fmt.Errorf("json marshal: %w", err)
-		}
-
-		cmd.log.WriteString(logrus.InfoLevel, string(serialized))
-	case OutputYAML:
-		serialized, err := yaml.Marshal(accessKey)
-		if err != nil {
-			return fmt.Errorf("yaml marshal: %w", err)
-		}
-
-		cmd.log.WriteString(logrus.InfoLevel, string(serialized))
-	default:
-		cmd.log.Infof("Loft host: %v", accessKey.LoftHost)
-		cmd.log.Infof("Access Key: %v", accessKey.AccessKey)
-
-		if accessKey.CaCert != "" {
-			cmd.log.Infof("CA Cert: %v", accessKey.CaCert)
-		}
-
-		if accessKey.Insecure {
-			cmd.log.Infof("Insecure: %v", accessKey.Insecure)
-		}
+	// the ca cert spans several lines, so it's only part of the json and yaml output
+	t := &printer.Table{
+		Header: []string{
+			"Loft Host",
+			"Access Key",
+			"Insecure",
+		},
+		Single: true,
 	}
+	t.AddRow(accessKey, clusterName, []string{
+		accessKey.LoftHost,
+		accessKey.AccessKey,
+		strconv.FormatBool(accessKey.Insecure),
+	})
 
-	return nil
+	return p.Print(t)
 }
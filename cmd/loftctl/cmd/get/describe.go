@@ -0,0 +1,223 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	clusterv1 "github.com/loft-sh/agentapi/v4/pkg/apis/loft/cluster/v1"
+	agentstoragev1 "github.com/loft-sh/agentapi/v4/pkg/apis/loft/storage/v1"
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/create"
+	"github.com/loft-sh/loftctl/v4/pkg/kube"
+	"github.com/loft-sh/log"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// instanceDescription holds the fields of a space or virtual cluster instance that are shown by get
+type instanceDescription struct {
+	Name        string
+	DisplayName string
+	Project     string
+	Cluster     string
+	Namespace   string
+	Owner       *storagev1.UserOrTeam
+	TemplateRef *storagev1.TemplateRef
+	Parameters  string
+	Phase       string
+	Reason      string
+	Message     string
+	Conditions  []instanceCondition
+	AccessRules []agentstoragev1.InstanceAccessRule
+	Annotations map[string]string
+	Created     time.Time
+	Events      []corev1.Event
+}
+
+type instanceCondition struct {
+	Type           string
+	Status         string
+	Reason         string
+	Message        string
+	LastTransition time.Time
+}
+
+// sleepModeAnnotations are shown in the sleep mode section in this order
+var sleepModeAnnotations = []struct {
+	annotation string
+	name       string
+}{
+	{annotation: clusterv1.SleepModeForceAnnotation, name: "Forced Sleep"},
+	{annotation: clusterv1.SleepModeForceDurationAnnotation, name: "Prevent Wakeup (s)"},
+	{annotation: clusterv1.SleepModeSleepAfterAnnotation, name: "Sleep After (s)"},
+	{annotation: clusterv1.SleepModeDeleteAfterAnnotation, name: "Delete After (s)"},
+	{annotation: clusterv1.SleepModeTimezoneAnnotation, name: "Timezone"},
+	{annotation: clusterv1.SleepModeLastActivityAnnotation, name: "Last Activity"},
+}
+
+// getInstanceEvents returns the events of the instance in the project namespace sorted by time. Users
+// that are not allowed to list events still get the rest of the description.
+func getInstanceEvents(ctx context.Context, managementClient kube.Interface, namespace, kind, name string, log log.Logger) []corev1.Event {
+	eventList, err := managementClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": kind,
+			"involvedObject.name": name,
+		}.String(),
+	})
+	if err != nil {
+		log.Debugf("Error listing events of %s %s: %v", kind, name, err)
+		return nil
+	}
+
+	events := eventList.Items
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	return events
+}
+
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	} else if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+
+	return event.CreationTimestamp.Time
+}
+
+// describe writes the description in the style of kubectl describe
+func (d *instanceDescription) describe(out io.Writer) error {
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", d.Name)
+	if d.DisplayName != "" {
+		fmt.Fprintf(w, "Display Name:\t%s\n", d.DisplayName)
+	}
+	fmt.Fprintf(w, "Project:\t%s\n", d.Project)
+	fmt.Fprintf(w, "Cluster:\t%s\n", orNone(d.Cluster))
+	fmt.Fprintf(w, "Namespace:\t%s\n", orNone(d.Namespace))
+	fmt.Fprintf(w, "Owner:\t%s\n", describeOwner(d.Owner))
+	fmt.Fprintf(w, "Created:\t%s (%s ago)\n", d.Created.Format(time.RFC1123Z), duration.HumanDuration(time.Since(d.Created)))
+	fmt.Fprintf(w, "Phase:\t%s\n", orNone(d.Phase))
+	if d.Reason != "" || d.Message != "" {
+		fmt.Fprintf(w, "Reason:\t%s\n", orNone(d.Reason))
+		fmt.Fprintf(w, "Message:\t%s\n", orNone(d.Message))
+	}
+
+	fmt.Fprintf(w, "Template:\n")
+	if d.TemplateRef == nil || d.TemplateRef.Name == "" {
+		fmt.Fprintf(w, "  <none>\n")
+	} else {
+		fmt.Fprintf(w, "  Name:\t%s\n", d.TemplateRef.Name)
+		fmt.Fprintf(w, "  Version:\t%s\n", orNone(d.TemplateRef.Version))
+	}
+
+	fmt.Fprintf(w, "Parameters:\n")
+	if strings.TrimSpace(d.Parameters) == "" {
+		fmt.Fprintf(w, "  <none>\n")
+	} else {
+		for _, line := range strings.Split(strings.TrimSpace(d.Parameters), "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
+
+	fmt.Fprintf(w, "Sleep Mode:\n")
+	fmt.Fprintf(w, "  Sleeping:\t%t\n", d.Phase == string(storagev1.InstanceSleeping))
+	for _, sleepMode := range sleepModeAnnotations {
+		value, ok := d.Annotations[sleepMode.annotation]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(w, "  %s:\t%s\n", sleepMode.name, value)
+	}
+
+	fmt.Fprintf(w, "Access Rules:\n")
+	if len(d.AccessRules) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	} else {
+		fmt.Fprintf(w, "  Cluster Role\tUsers\tTeams\n")
+		fmt.Fprintf(w, "  ------------\t-----\t-----\n")
+		for _, rule := range d.AccessRules {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", orNone(rule.ClusterRole), orNone(strings.Join(rule.Users, ",")), orNone(strings.Join(rule.Teams, ",")))
+		}
+	}
+
+	fmt.Fprintf(w, "Links:\n")
+	links := strings.TrimSpace(d.Annotations[create.LoftCustomLinksAnnotation])
+	if links == "" {
+		fmt.Fprintf(w, "  <none>\n")
+	} else {
+		for _, link := range strings.Split(links, create.LoftCustomLinksDelimiter) {
+			name, url, found := strings.Cut(link, "=")
+			if found {
+				fmt.Fprintf(w, "  %s:\t%s\n", name, url)
+			} else {
+				fmt.Fprintf(w, "  %s\n", link)
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "Conditions:\n")
+	if len(d.Conditions) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	} else {
+		fmt.Fprintf(w, "  Type\tStatus\tLast Transition\tReason\tMessage\n")
+		fmt.Fprintf(w, "  ----\t------\t---------------\t------\t-------\n")
+		for _, condition := range d.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", condition.Type, condition.Status, describeAge(condition.LastTransition), orNone(condition.Reason), orNone(condition.Message))
+		}
+	}
+
+	fmt.Fprintf(w, "Events:\n")
+	if len(d.Events) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	} else {
+		fmt.Fprintf(w, "  Type\tReason\tAge\tFrom\tMessage\n")
+		fmt.Fprintf(w, "  ----\t------\t---\t----\t-------\n")
+		for _, event := range d.Events {
+			age := describeAge(eventTime(event))
+			if event.Count > 1 {
+				age = fmt.Sprintf("%s (x%d)", age, event.Count)
+			}
+
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", event.Type, event.Reason, age, orNone(event.Source.Component), strings.TrimSpace(event.Message))
+		}
+	}
+
+	return w.Flush()
+}
+
+func describeOwner(owner *storagev1.UserOrTeam) string {
+	if owner == nil || (owner.User == "" && owner.Team == "") {
+		return "<none>"
+	} else if owner.Team != "" {
+		return "team/" + owner.Team
+	}
+
+	return "user/" + owner.User
+}
+
+func describeAge(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+
+	return duration.HumanDuration(time.Since(t))
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+
+	return value
+}
@@ -28,5 +28,7 @@ func NewGetCmd(globalFlags *flags.GlobalFlags, defaults *pdefaults.Defaults) *co
 	c.AddCommand(NewUserCmd(globalFlags))
 	c.AddCommand(NewSecretCmd(globalFlags, defaults))
 	c.AddCommand(NewClusterAccessKeyCmd(globalFlags))
+	c.AddCommand(NewVClusterCmd(globalFlags, defaults))
+	c.AddCommand(NewSpaceCmd(globalFlags, defaults))
 	return c
 }
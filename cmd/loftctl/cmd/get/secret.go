@@ -2,9 +2,9 @@ package get
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/loft-sh/api/v4/pkg/product"
@@ -12,6 +12,7 @@ import (
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	pdefaults "github.com/loft-sh/loftctl/v4/pkg/defaults"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
 	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/loftctl/v4/pkg/util"
@@ -19,15 +20,11 @@ import (
 	"github.com/loft-sh/log/survey"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const (
-	OutputYAML  string = "yaml"
-	OutputJSON  string = "json"
-	OutputValue string = "value"
-)
+// OutputValue prints only the raw value of the secret key
+const OutputValue string = "value"
 
 // SecretCmd holds the flags
 type SecretCmd struct {
@@ -82,12 +79,33 @@ devspace get secret test-secret.key --project myproject
 	c.Flags().StringVarP(&cmd.Project, "project", "p", p, "The project to read the project secret from.")
 	c.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", product.Replace("The namespace in the loft cluster to read the secret from. If omitted will use the namespace where loft is installed in"))
 	c.Flags().BoolVarP(&cmd.All, "all", "a", false, "Display all secret keys")
-	c.Flags().StringVarP(&cmd.Output, "output", "o", "", "Output format. One of: (json, yaml, value). If the --all flag is passed 'yaml' will be the default format")
+	c.Flags().StringVarP(&cmd.Output, "output", "o", "", "Output format. One of: (value, "+printer.Formats+"). If the --all flag is passed 'yaml' will be the default format")
 	return c
 }
 
 // RunUsers executes the functionality
 func (cmd *SecretCmd) Run(ctx context.Context, args []string) error {
+	output := cmd.Output
+
+	if cmd.All && output == "" {
+		output = printer.OutputYAML
+	} else if output == "" {
+		output = OutputValue
+	}
+
+	if cmd.All && output == OutputValue {
+		return errors.Errorf("output format %s is not allowed with the --all flag.", OutputValue)
+	}
+
+	var p *printer.Printer
+	if output != OutputValue {
+		var err error
+		p, err = printer.New(output, cmd.log)
+		if err != nil {
+			return err
+		}
+	}
+
 	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
 	if err != nil {
 		return err
@@ -106,18 +124,6 @@ func (cmd *SecretCmd) Run(ctx context.Context, args []string) error {
 		secretType = set.SharedSecret
 	}
 
-	output := cmd.Output
-
-	if cmd.All && output == "" {
-		output = OutputYAML
-	} else if output == "" {
-		output = OutputValue
-	}
-
-	if cmd.All && output == OutputValue {
-		return errors.Errorf("output format %s is not allowed with the --all flag.", OutputValue)
-	}
-
 	// get target namespace
 	var namespace string
 
@@ -235,34 +241,39 @@ func (cmd *SecretCmd) Run(ctx context.Context, args []string) error {
 		}
 	}
 
-	var outputData []byte
 	if output == OutputValue {
-		var ok bool
-		outputData, ok = kvs[keyName]
+		value, ok := kvs[keyName]
 		if !ok {
 			return errors.Errorf("key %s does not exist in secret %s", keyName, secretName)
 		}
-	} else {
-		stringValues := map[string]string{}
-		for k, v := range kvs {
-			stringValues[k] = string(v)
-		}
 
-		if output == OutputYAML {
-			outputData, err = yaml.Marshal(stringValues)
-			if err != nil {
-				return err
-			}
-		} else if output == OutputJSON {
-			outputData, err = json.Marshal(stringValues)
-			if err != nil {
-				return err
-			}
-		} else {
-			return errors.Errorf("unknown output format %s, allowed formats are: value, json, yaml", output)
+		_, err = os.Stdout.Write(value)
+		return err
+	}
+
+	stringValues := map[string]string{}
+	keys := []string{}
+	for k, v := range kvs {
+		stringValues[k] = string(v)
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// tables list every key in its own row, all other formats print the keys and values as one object
+	t := &printer.Table{
+		Header: []string{
+			"Key",
+			"Value",
+		},
+		Single: true,
+	}
+	if p.IsTable() || output == printer.OutputName {
+		for _, k := range keys {
+			t.AddRow(nil, k, []string{k, stringValues[k]})
 		}
+	} else {
+		t.AddRow(stringValues, secretName, nil)
 	}
 
-	_, err = os.Stdout.Write(outputData)
-	return err
+	return p.Print(t)
 }
@@ -0,0 +1,145 @@
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
+	pdefaults "github.com/loft-sh/loftctl/v4/pkg/defaults"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/loftctl/v4/pkg/util"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SpaceCmd holds the cmd flags
+type SpaceCmd struct {
+	*flags.GlobalFlags
+
+	Project string
+	Output  string
+
+	log log.Logger
+}
+
+// NewSpaceCmd creates a new command
+func NewSpaceCmd(globalFlags *flags.GlobalFlags, defaults *pdefaults.Defaults) *cobra.Command {
+	cmd := &SpaceCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("get space", `
+Shows the owner, template, parameters, status conditions,
+sleep mode, access rules, links and events of a space
+
+Example:
+loft get space myspace
+loft get space myspace --project myproject -o yaml
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+########################################################
+################## devspace get space ##################
+########################################################
+Shows the owner, template, parameters, status conditions,
+sleep mode, access rules, links and events of a space
+
+Example:
+devspace get space myspace
+devspace get space myspace --project myproject -o yaml
+########################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "space" + util.SpaceNameOnlyUseLine,
+		Short: "Shows the details of a space",
+		Long:  description,
+		Args:  util.SpaceNameOnlyValidator,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	p, _ := defaults.Get(pdefaults.KeyProject, "")
+	c.Flags().StringVarP(&cmd.Project, "project", "p", p, "The project to use")
+	printer.AddFlag(c.Flags(), &cmd.Output)
+	return c
+}
+
+// Run executes the functionality
+func (cmd *SpaceCmd) Run(ctx context.Context, args []string) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	spaceName := ""
+	if len(args) > 0 {
+		spaceName = args[0]
+	}
+
+	_, cmd.Project, spaceName, err = helper.SelectSpaceInstanceOrSpace(ctx, baseClient, spaceName, cmd.Project, "", cmd.log)
+	if err != nil {
+		return err
+	} else if cmd.Project == "" {
+		return fmt.Errorf("space %s is not part of a project and cannot be shown", spaceName)
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	namespace := projectutil.ProjectNamespace(cmd.Project)
+	spaceInstance, err := managementClient.Loft().ManagementV1().SpaceInstances(namespace).Get(ctx, spaceName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if !p.IsTable() {
+		t := &printer.Table{Single: true}
+		t.AddRow(spaceInstance, spaceInstance.Name, nil)
+		return p.Print(t)
+	}
+
+	description := &instanceDescription{
+		Name:        spaceInstance.Name,
+		DisplayName: spaceInstance.Spec.DisplayName,
+		Project:     cmd.Project,
+		Cluster:     spaceInstance.Spec.ClusterRef.Cluster,
+		Namespace:   spaceInstance.Spec.ClusterRef.Namespace,
+		Owner:       spaceInstance.Spec.Owner,
+		TemplateRef: spaceInstance.Spec.TemplateRef,
+		Parameters:  spaceInstance.Spec.Parameters,
+		Phase:       string(spaceInstance.Status.Phase),
+		Reason:      spaceInstance.Status.Reason,
+		Message:     spaceInstance.Status.Message,
+		AccessRules: spaceInstance.Spec.ExtraAccessRules,
+		Annotations: spaceInstance.Annotations,
+		Created:     spaceInstance.CreationTimestamp.Time,
+		Events:      getInstanceEvents(ctx, managementClient, namespace, "SpaceInstance", spaceInstance.Name, cmd.log),
+	}
+	for _, condition := range spaceInstance.Status.Conditions {
+		description.Conditions = append(description.Conditions, instanceCondition{
+			Type:           string(condition.Type),
+			Status:         string(condition.Status),
+			Reason:         condition.Reason,
+			Message:        condition.Message,
+			LastTransition: condition.LastTransitionTime.Time,
+		})
+	}
+
+	return description.describe(p.Out)
+}
@@ -2,14 +2,15 @@ package get
 
 import (
 	"context"
+	"strings"
 
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
-	"github.com/loft-sh/log/table"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,8 @@ import (
 type UserCmd struct {
 	*flags.GlobalFlags
 
+	Output string
+
 	log log.Logger
 }
 
@@ -56,11 +59,17 @@ devspace get user
 		},
 	}
 
+	printer.AddFlag(c.Flags(), &cmd.Output)
 	return c
 }
 
 // RunUsers executes the functionality
 func (cmd *UserCmd) Run(ctx context.Context) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
 	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
 	if err != nil {
 		return err
@@ -78,20 +87,29 @@ func (cmd *UserCmd) Run(ctx context.Context) error {
 		return errors.New("logged in with a team and not a user")
 	}
 
-	header := []string{
-		"Username",
-		"Kubernetes Name",
-		"Display Name",
-		"Email",
+	t := &printer.Table{
+		Header: []string{
+			"Username",
+			"Kubernetes Name",
+			"Display Name",
+			"Email",
+		},
+		WideHeader: []string{
+			"Teams",
+		},
+		Single: true,
+	}
+
+	teams := []string{}
+	for _, team := range userName.Teams {
+		teams = append(teams, team.Name)
 	}
-	values := [][]string{}
-	values = append(values, []string{
+	t.AddRow(userName, userName.Name, []string{
 		userName.Username,
 		userName.Name,
 		userName.DisplayName,
 		userName.Email,
-	})
+	}, strings.Join(teams, ","))
 
-	table.PrintTable(cmd.log, header, values)
-	return nil
+	return p.Print(t)
 }
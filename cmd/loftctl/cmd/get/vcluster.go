@@ -0,0 +1,145 @@
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
+	pdefaults "github.com/loft-sh/loftctl/v4/pkg/defaults"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/loftctl/v4/pkg/util"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VClusterCmd holds the cmd flags
+type VClusterCmd struct {
+	*flags.GlobalFlags
+
+	Project string
+	Output  string
+
+	log log.Logger
+}
+
+// NewVClusterCmd creates a new command
+func NewVClusterCmd(globalFlags *flags.GlobalFlags, defaults *pdefaults.Defaults) *cobra.Command {
+	cmd := &VClusterCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("get vcluster", `
+Shows the owner, template, parameters, status conditions,
+sleep mode, access rules, links and events of a vcluster
+
+Example:
+loft get vcluster myvcluster
+loft get vcluster myvcluster --project myproject -o yaml
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+########################################################
+################ devspace get vcluster #################
+########################################################
+Shows the owner, template, parameters, status conditions,
+sleep mode, access rules, links and events of a vcluster
+
+Example:
+devspace get vcluster myvcluster
+devspace get vcluster myvcluster --project myproject -o yaml
+########################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "vcluster" + util.VClusterNameOnlyUseLine,
+		Short: "Shows the details of a vcluster",
+		Long:  description,
+		Args:  util.VClusterNameOnlyValidator,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+
+	p, _ := defaults.Get(pdefaults.KeyProject, "")
+	c.Flags().StringVarP(&cmd.Project, "project", "p", p, "The project to use")
+	printer.AddFlag(c.Flags(), &cmd.Output)
+	return c
+}
+
+// Run executes the functionality
+func (cmd *VClusterCmd) Run(ctx context.Context, args []string) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	vClusterName := ""
+	if len(args) > 0 {
+		vClusterName = args[0]
+	}
+
+	_, cmd.Project, _, vClusterName, err = helper.SelectVirtualClusterInstanceOrVirtualCluster(ctx, baseClient, vClusterName, "", cmd.Project, "", cmd.log)
+	if err != nil {
+		return err
+	} else if cmd.Project == "" {
+		return fmt.Errorf("vcluster %s is not part of a project and cannot be shown", vClusterName)
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	namespace := projectutil.ProjectNamespace(cmd.Project)
+	virtualClusterInstance, err := managementClient.Loft().ManagementV1().VirtualClusterInstances(namespace).Get(ctx, vClusterName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if !p.IsTable() {
+		t := &printer.Table{Single: true}
+		t.AddRow(virtualClusterInstance, virtualClusterInstance.Name, nil)
+		return p.Print(t)
+	}
+
+	description := &instanceDescription{
+		Name:        virtualClusterInstance.Name,
+		DisplayName: virtualClusterInstance.Spec.DisplayName,
+		Project:     cmd.Project,
+		Cluster:     virtualClusterInstance.Spec.ClusterRef.Cluster,
+		Namespace:   virtualClusterInstance.Spec.ClusterRef.Namespace,
+		Owner:       virtualClusterInstance.Spec.Owner,
+		TemplateRef: virtualClusterInstance.Spec.TemplateRef,
+		Parameters:  virtualClusterInstance.Spec.Parameters,
+		Phase:       string(virtualClusterInstance.Status.Phase),
+		Reason:      virtualClusterInstance.Status.Reason,
+		Message:     virtualClusterInstance.Status.Message,
+		AccessRules: virtualClusterInstance.Spec.ExtraAccessRules,
+		Annotations: virtualClusterInstance.Annotations,
+		Created:     virtualClusterInstance.CreationTimestamp.Time,
+		Events:      getInstanceEvents(ctx, managementClient, namespace, "VirtualClusterInstance", virtualClusterInstance.Name, cmd.log),
+	}
+	for _, condition := range virtualClusterInstance.Status.Conditions {
+		description.Conditions = append(description.Conditions, instanceCondition{
+			Type:           string(condition.Type),
+			Status:         string(condition.Status),
+			Reason:         condition.Reason,
+			Message:        condition.Message,
+			LastTransition: condition.LastTransitionTime.Time,
+		})
+	}
+
+	return description.describe(p.Out)
+}
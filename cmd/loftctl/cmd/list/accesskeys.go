@@ -0,0 +1,181 @@
+package list
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	managementv1 "github.com/loft-sh/api/v4/pkg/apis/management/v1"
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// AccessKeysCmd holds the cmd flags
+type AccessKeysCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+
+	log log.Logger
+}
+
+// NewAccessKeysCmd creates a new command
+func NewAccessKeysCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &AccessKeysCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("list accesskeys", `
+List the access keys of the current user
+
+Example:
+loft list accesskeys
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+########################################################
+############### devspace list accesskeys ###############
+########################################################
+List the access keys of the current user
+
+Example:
+devspace list accesskeys
+########################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "accesskeys",
+		Short: "Lists the access keys of the current user",
+		Long:  description,
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+
+	printer.AddFlag(c.Flags(), &cmd.Output)
+	return c
+}
+
+// Run executes the functionality
+func (cmd *AccessKeysCmd) Run(ctx context.Context) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	accessKeys, err := managementClient.Loft().ManagementV1().OwnedAccessKeys().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(accessKeys.Items, func(i, j int) bool {
+		return accessKeys.Items[i].CreationTimestamp.Before(&accessKeys.Items[j].CreationTimestamp)
+	})
+
+	t := &printer.Table{
+		Header: []string{
+			"Name",
+			"Display Name",
+			"Type",
+			"Owner",
+			"Scope",
+			"Last Activity",
+			"Expires",
+			"Age",
+		},
+	}
+	for idx := range accessKeys.Items {
+		accessKey := &accessKeys.Items[idx]
+		owner := accessKey.Spec.User
+		if accessKey.Spec.Team != "" {
+			owner = "team/" + accessKey.Spec.Team
+		}
+
+		// never print the keys themselves
+		accessKey.Spec.Key = ""
+		t.AddRow(accessKey, accessKey.Name, []string{
+			accessKey.Name,
+			accessKey.Spec.DisplayName,
+			string(accessKey.Spec.Type),
+			owner,
+			accessKeyScope(accessKey),
+			accessKeyLastActivity(accessKey),
+			accessKeyExpires(accessKey),
+			duration.HumanDuration(time.Since(accessKey.CreationTimestamp.Time)),
+		})
+	}
+
+	return p.Print(t)
+}
+
+func accessKeyScope(accessKey *managementv1.OwnedAccessKey) string {
+	scope := accessKey.Spec.Scope
+	if scope == nil {
+		return "all"
+	}
+
+	var scopes []string
+	for _, project := range scope.Projects {
+		scopes = append(scopes, "project/"+project.Project)
+	}
+	for _, space := range scope.Spaces {
+		scopes = append(scopes, "space/"+space.Project+"/"+space.Space)
+	}
+	for _, virtualCluster := range scope.VirtualClusters {
+		scopes = append(scopes, "vcluster/"+virtualCluster.Project+"/"+virtualCluster.VirtualCluster)
+	}
+	for _, cluster := range scope.Clusters {
+		scopes = append(scopes, "cluster/"+cluster.Cluster)
+	}
+	if len(scopes) == 0 {
+		return "custom"
+	}
+
+	return strings.Join(scopes, ",")
+}
+
+func accessKeyLastActivity(accessKey *managementv1.OwnedAccessKey) string {
+	if accessKey.Status.LastActivity == nil {
+		return "never"
+	}
+
+	return duration.HumanDuration(time.Since(time.Unix(*accessKey.Status.LastActivity, 0))) + " ago"
+}
+
+func accessKeyExpires(accessKey *managementv1.OwnedAccessKey) string {
+	if accessKey.Spec.TTL <= 0 {
+		return "never"
+	}
+
+	start := accessKey.CreationTimestamp.Time
+	if accessKey.Spec.TTLAfterLastActivity && accessKey.Status.LastActivity != nil {
+		start = time.Unix(*accessKey.Status.LastActivity, 0)
+	}
+
+	expires := start.Add(time.Duration(accessKey.Spec.TTL) * time.Second)
+	if time.Now().After(expires) {
+		return "expired"
+	}
+
+	return "in " + duration.HumanDuration(time.Until(expires))
+}
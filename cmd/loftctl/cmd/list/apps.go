@@ -0,0 +1,115 @@
+package list
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// AppsCmd holds the cmd flags
+type AppsCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+
+	log log.Logger
+}
+
+// NewAppsCmd creates a new command
+func NewAppsCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &AppsCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("list apps", `
+List the loft apps you have access to, together with
+their versions
+
+Example:
+loft list apps
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+########################################################
+################# devspace list apps ###################
+########################################################
+List the loft apps you have access to, together with
+their versions
+
+Example:
+devspace list apps
+########################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "apps",
+		Short: product.Replace("Lists the loft apps you have access to"),
+		Long:  description,
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+
+	printer.AddFlag(c.Flags(), &cmd.Output)
+	return c
+}
+
+// Run executes the functionality
+func (cmd *AppsCmd) Run(ctx context.Context) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	appList, err := managementClient.Loft().ManagementV1().Apps().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	t := &printer.Table{
+		Header: []string{
+			"App",
+			"Display Name",
+			"Latest Version",
+			"Age",
+		},
+		WideHeader: []string{
+			"Versions",
+			"Description",
+		},
+	}
+	for idx := range appList.Items {
+		app := &appList.Items[idx]
+		latestVersion, versions := templateVersions(app)
+		t.AddRow(app, app.Name, []string{
+			app.Name,
+			app.Spec.DisplayName,
+			latestVersion,
+			duration.HumanDuration(time.Since(app.CreationTimestamp.Time)),
+		}, strings.Join(versions, ", "), app.Spec.Description)
+	}
+
+	return p.Print(t)
+}
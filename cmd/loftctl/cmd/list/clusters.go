@@ -7,9 +7,9 @@ import (
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
-	"github.com/loft-sh/log/table"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -19,6 +19,8 @@ import (
 type ClustersCmd struct {
 	*flags.GlobalFlags
 
+	Output string
+
 	log log.Logger
 }
 
@@ -57,11 +59,17 @@ devspace list clusters
 		},
 	}
 
+	printer.AddFlag(clustersCmd.Flags(), &cmd.Output)
 	return clustersCmd
 }
 
 // RunClusters executes the functionality
 func (cmd *ClustersCmd) RunClusters(ctx context.Context) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
 	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
 	if err != nil {
 		return err
@@ -77,18 +85,22 @@ func (cmd *ClustersCmd) RunClusters(ctx context.Context) error {
 		return err
 	}
 
-	header := []string{
-		"Cluster",
-		"Age",
+	t := &printer.Table{
+		Header: []string{
+			"Cluster",
+			"Age",
+		},
+		WideHeader: []string{
+			"Display Name",
+		},
 	}
-	values := [][]string{}
-	for _, cluster := range clusterList.Items {
-		values = append(values, []string{
+	for idx := range clusterList.Items {
+		cluster := &clusterList.Items[idx]
+		t.AddRow(cluster, cluster.Name, []string{
 			cluster.Name,
 			duration.HumanDuration(time.Since(cluster.CreationTimestamp.Time)),
-		})
+		}, cluster.Spec.DisplayName)
 	}
 
-	table.PrintTable(cmd.log, header, values)
-	return nil
+	return p.Print(t)
 }
@@ -0,0 +1,143 @@
+package list
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// sortByFields are the supported values of --sort-by
+var sortByFields = []string{"name", "project", "cluster", "phase", "age"}
+
+// instanceFilter holds the flags to filter and sort spaces and virtual clusters
+type instanceFilter struct {
+	Projects []string
+	Selector string
+	Clusters []string
+	Phases   []string
+	Owners   []string
+	SortBy   string
+
+	selector labels.Selector
+}
+
+// instanceRow is a printed space or virtual cluster together with the fields it's filtered and sorted by
+type instanceRow struct {
+	printer.Row
+
+	Key     string
+	Project string
+	Cluster string
+	Phase   string
+	Owner   *storagev1.UserOrTeam
+	Labels  map[string]string
+	Created time.Time
+}
+
+// AddFlags adds the filter flags, kind is the plural of the listed objects
+func (f *instanceFilter) AddFlags(flags *flag.FlagSet, kind string) {
+	flags.StringSliceVar(&f.Projects, "project", []string{}, "If set, only lists the "+kind+" of the given projects")
+	flags.StringVarP(&f.Selector, "selector", "l", "", "If set, only lists the "+kind+" matching the label selector, e.g. -l key1=value1,key2=value2")
+	flags.StringSliceVar(&f.Clusters, "cluster", []string{}, "If set, only lists the "+kind+" on the given clusters")
+	flags.StringSliceVar(&f.Phases, "phase", []string{}, "If set, only lists the "+kind+" in the given phases, e.g. Ready or Sleeping")
+	flags.StringSliceVar(&f.Owners, "owner", []string{}, "If set, only lists the "+kind+" owned by the given users or teams")
+	flags.StringVar(&f.SortBy, "sort-by", "", "Sorts the "+kind+" by one of: "+strings.Join(sortByFields, ", "))
+}
+
+// Validate parses the selector and checks the sort field
+func (f *instanceFilter) Validate() error {
+	selector, err := labels.Parse(f.Selector)
+	if err != nil {
+		return fmt.Errorf("parse --selector: %w", err)
+	}
+	f.selector = selector
+
+	if f.SortBy != "" && !slices.Contains(sortByFields, f.SortBy) {
+		return fmt.Errorf("invalid --sort-by %s, needs to be one of: %s", f.SortBy, strings.Join(sortByFields, ", "))
+	}
+
+	return nil
+}
+
+// ListOptions returns the filters that are pushed down into the list calls
+func (f *instanceFilter) ListOptions() helper.InstanceListOptions {
+	return helper.InstanceListOptions{
+		Projects:      f.Projects,
+		LabelSelector: f.Selector,
+	}
+}
+
+// Matches checks all filters, so rows that were not listed with ListOptions such as legacy
+// spaces and virtual clusters are filtered as well
+func (f *instanceFilter) Matches(row instanceRow) bool {
+	if len(f.Projects) > 0 && !slices.Contains(f.Projects, row.Project) {
+		return false
+	} else if f.selector != nil && !f.selector.Matches(labels.Set(row.Labels)) {
+		return false
+	} else if len(f.Clusters) > 0 && !slices.Contains(f.Clusters, row.Cluster) {
+		return false
+	} else if len(f.Phases) > 0 && !slices.ContainsFunc(f.Phases, func(phase string) bool { return strings.EqualFold(phase, row.Phase) }) {
+		return false
+	} else if len(f.Owners) > 0 && (row.Owner == nil || !(slices.Contains(f.Owners, row.Owner.User) || slices.Contains(f.Owners, row.Owner.Team))) {
+		return false
+	}
+
+	return true
+}
+
+// Filter returns the matching rows sorted by the sort field. Without sort field, the order is kept.
+func (f *instanceFilter) Filter(rows []instanceRow) []instanceRow {
+	filtered := []instanceRow{}
+	for _, row := range rows {
+		if f.Matches(row) {
+			filtered = append(filtered, row)
+		}
+	}
+
+	f.Sort(filtered)
+	return filtered
+}
+
+// Sort sorts the rows by the sort field and by key for equal fields
+func (f *instanceFilter) Sort(rows []instanceRow) {
+	if f.SortBy == "" {
+		return
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		switch f.SortBy {
+		case "name":
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		case "project":
+			if a.Project != b.Project {
+				return a.Project < b.Project
+			}
+		case "cluster":
+			if a.Cluster != b.Cluster {
+				return a.Cluster < b.Cluster
+			}
+		case "phase":
+			if a.Phase != b.Phase {
+				return a.Phase < b.Phase
+			}
+		case "age":
+			// youngest first, same as the age column ascending
+			if !a.Created.Equal(b.Created) {
+				return a.Created.After(b.Created)
+			}
+		}
+
+		return a.Key < b.Key
+	})
+}
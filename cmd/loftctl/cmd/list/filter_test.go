@@ -0,0 +1,49 @@
+package list
+
+import (
+	"testing"
+	"time"
+
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"gotest.tools/v3/assert"
+)
+
+func TestInstanceFilter(t *testing.T) {
+	now := time.Now()
+	rows := []instanceRow{
+		{Row: printer.Row{Name: "b"}, Key: "p1/b", Project: "p1", Cluster: "c1", Phase: "Ready", Owner: &storagev1.UserOrTeam{User: "alice"}, Labels: map[string]string{"team": "backend"}, Created: now.Add(-time.Hour)},
+		{Row: printer.Row{Name: "a"}, Key: "p2/a", Project: "p2", Cluster: "c2", Phase: "Sleeping", Owner: &storagev1.UserOrTeam{Team: "devs"}, Created: now},
+		{Row: printer.Row{Name: "legacy"}, Key: "c1/legacy", Cluster: "c1", Phase: "Active", Created: now.Add(-2 * time.Hour)},
+	}
+	names := func(rows []instanceRow) []string {
+		names := []string{}
+		for _, row := range rows {
+			names = append(names, row.Name)
+		}
+		return names
+	}
+
+	tests := map[string]struct {
+		filter   instanceFilter
+		expected []string
+	}{
+		"no filter keeps order":     {filter: instanceFilter{}, expected: []string{"b", "a", "legacy"}},
+		"project":                   {filter: instanceFilter{Projects: []string{"p2"}}, expected: []string{"a"}},
+		"selector":                  {filter: instanceFilter{Selector: "team=backend"}, expected: []string{"b"}},
+		"cluster":                   {filter: instanceFilter{Clusters: []string{"c1"}}, expected: []string{"b", "legacy"}},
+		"phase is case-insensitive": {filter: instanceFilter{Phases: []string{"sleeping"}}, expected: []string{"a"}},
+		"owner user or team":        {filter: instanceFilter{Owners: []string{"alice", "devs"}}, expected: []string{"b", "a"}},
+		"sort by name":              {filter: instanceFilter{SortBy: "name"}, expected: []string{"a", "b", "legacy"}},
+		"sort by age":               {filter: instanceFilter{SortBy: "age"}, expected: []string{"a", "b", "legacy"}},
+		"sort by phase":             {filter: instanceFilter{SortBy: "phase"}, expected: []string{"legacy", "b", "a"}},
+	}
+	for name, test := range tests {
+		filter := test.filter
+		assert.NilError(t, filter.Validate(), name)
+		assert.DeepEqual(t, names(filter.Filter(rows)), test.expected)
+	}
+
+	assert.ErrorContains(t, (&instanceFilter{SortBy: "size"}).Validate(), "invalid --sort-by size")
+	assert.ErrorContains(t, (&instanceFilter{Selector: "a in (b"}).Validate(), "parse --selector")
+}
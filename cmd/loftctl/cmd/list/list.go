@@ -29,5 +29,10 @@ func NewListCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
 	listCmd.AddCommand(NewClustersCmd(globalFlags))
 	listCmd.AddCommand(NewVirtualClustersCmd(globalFlags))
 	listCmd.AddCommand(NewSharedSecretsCmd(globalFlags))
+	listCmd.AddCommand(NewAccessKeysCmd(globalFlags))
+	listCmd.AddCommand(NewProjectsCmd(globalFlags))
+	listCmd.AddCommand(NewTemplatesCmd(globalFlags))
+	listCmd.AddCommand(NewUsersCmd(globalFlags))
+	listCmd.AddCommand(NewAppsCmd(globalFlags))
 	return listCmd
 }
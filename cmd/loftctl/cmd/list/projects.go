@@ -0,0 +1,110 @@
+package list
+
+import (
+	"context"
+	"time"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// ProjectsCmd holds the cmd flags
+type ProjectsCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+
+	log log.Logger
+}
+
+// NewProjectsCmd creates a new command
+func NewProjectsCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ProjectsCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("list projects", `
+List the loft projects you have access to
+
+Example:
+loft list projects
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+########################################################
+############### devspace list projects #################
+########################################################
+List the loft projects you have access to
+
+Example:
+devspace list projects
+########################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "projects",
+		Short: product.Replace("Lists the loft projects you have access to"),
+		Long:  description,
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+
+	printer.AddFlag(c.Flags(), &cmd.Output)
+	return c
+}
+
+// Run executes the functionality
+func (cmd *ProjectsCmd) Run(ctx context.Context) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	projectList, err := managementClient.Loft().ManagementV1().Projects().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	t := &printer.Table{
+		Header: []string{
+			"Project",
+			"Display Name",
+			"Owner",
+			"Age",
+		},
+		WideHeader: []string{
+			"Description",
+		},
+	}
+	for idx := range projectList.Items {
+		project := &projectList.Items[idx]
+		t.AddRow(project, project.Name, []string{
+			project.Name,
+			project.Spec.DisplayName,
+			ownerName(project.Spec.Owner),
+			duration.HumanDuration(time.Since(project.CreationTimestamp.Time)),
+		}, project.Spec.Description)
+	}
+
+	return p.Print(t)
+}
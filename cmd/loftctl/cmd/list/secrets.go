@@ -2,6 +2,7 @@ package list
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,9 +12,9 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	"github.com/loft-sh/loftctl/v4/pkg/kube"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
-	"github.com/loft-sh/log/table"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -26,6 +27,7 @@ type SharedSecretsCmd struct {
 	Project     []string
 	All         bool
 	AllProjects bool
+	Output      string
 
 	log log.Logger
 }
@@ -69,11 +71,17 @@ devspace list secrets
 	c.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", product.Replace("The namespace in the loft cluster to read global secrets from. If omitted will query all accessible global secrets"))
 	c.Flags().BoolVarP(&cmd.All, "all", "a", false, "Display global and project secrets. May be used with the --project flag to display global secrets and a subset of project secrets")
 	c.Flags().BoolVar(&cmd.AllProjects, "all-projects", false, "Display project secrets for all projects.")
+	printer.AddFlag(c.Flags(), &cmd.Output)
 	return c
 }
 
 // Run executes the functionality
 func (cmd *SharedSecretsCmd) Run(command *cobra.Command, _ []string) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
 	baseClient, err := client.InitClientFromPath(command.Context(), cmd.Config)
 	if err != nil {
 		return err
@@ -101,133 +109,108 @@ func (cmd *SharedSecretsCmd) Run(command *cobra.Command, _ []string) error {
 			return err
 		}
 
-		return cmd.printAllSecrets(sharedSecrets, projectSecrets)
+		return cmd.printAllSecrets(p, sharedSecrets, projectSecrets)
 	} else if cmd.AllProjects {
 		projectSecrets, err := helper.GetProjectSecrets(command.Context(), managementClient)
 		if err != nil {
 			return err
 		}
 
-		return cmd.printProjectSecrets(projectSecrets)
+		return cmd.printProjectSecrets(p, projectSecrets)
 	} else {
 		if len(cmd.Project) == 0 {
-			return cmd.printSharedSecrets(command.Context(), managementClient, cmd.Namespace)
+			return cmd.printSharedSecrets(command.Context(), p, managementClient, cmd.Namespace)
 		} else {
 			projectSecrets, err := helper.GetProjectSecrets(command.Context(), managementClient, cmd.Project...)
 			if err != nil {
 				return err
 			}
 
-			return cmd.printProjectSecrets(projectSecrets)
+			return cmd.printProjectSecrets(p, projectSecrets)
 		}
 	}
 }
 
-func (cmd *SharedSecretsCmd) printSharedSecrets(ctx context.Context, managementClient kube.Interface, namespace string) error {
+var secretsHeader = []string{
+	"Name",
+	"Namespace",
+	"Project",
+	"Keys",
+	"Age",
+}
+
+func (cmd *SharedSecretsCmd) printSharedSecrets(ctx context.Context, p *printer.Printer, managementClient kube.Interface, namespace string) error {
 	secrets, err := managementClient.Loft().ManagementV1().SharedSecrets(namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return err
 	}
 
-	header := []string{
-		"Name",
-		"Namespace",
-		"Keys",
-		"Age",
+	t := &printer.Table{
+		Header: []string{
+			"Name",
+			"Namespace",
+			"Keys",
+			"Age",
+		},
 	}
-	var values [][]string
-	for _, secret := range secrets.Items {
-		var keyNames []string
-		for k := range secret.Spec.Data {
-			keyNames = append(keyNames, k)
-		}
-
-		values = append(values, []string{
+	for idx := range secrets.Items {
+		secret := &secrets.Items[idx]
+		t.AddRow(secret, secret.Name, []string{
 			secret.Name,
 			secret.Namespace,
-			strings.Join(keyNames, ","),
+			strings.Join(secretKeys(secret.Spec.Data), ","),
 			duration.HumanDuration(time.Since(secret.CreationTimestamp.Time)),
 		})
 	}
 
-	table.PrintTable(cmd.log, header, values)
-	return nil
+	return p.Print(t)
 }
 
-func (cmd *SharedSecretsCmd) printProjectSecrets(projectSecrets []*helper.ProjectProjectSecret) error {
-	header := []string{
-		"Name",
-		"Namespace",
-		"Project",
-		"Keys",
-		"Age",
-	}
-	var values [][]string
-	for _, secret := range projectSecrets {
-		projectSecret := secret.ProjectSecret
-		var keyNames []string
-
-		for k := range projectSecret.Spec.Data {
-			keyNames = append(keyNames, k)
-		}
-
-		values = append(values, []string{
-			projectSecret.Name,
-			projectSecret.Namespace,
-			secret.Project,
-			strings.Join(keyNames, ","),
-			duration.HumanDuration(time.Since(projectSecret.CreationTimestamp.Time)),
-		})
-	}
-
-	table.PrintTable(cmd.log, header, values)
-	return nil
+func (cmd *SharedSecretsCmd) printProjectSecrets(p *printer.Printer, projectSecrets []*helper.ProjectProjectSecret) error {
+	t := &printer.Table{Header: secretsHeader}
+	addProjectSecrets(t, projectSecrets)
+	return p.Print(t)
 }
 
 func (cmd *SharedSecretsCmd) printAllSecrets(
+	p *printer.Printer,
 	sharedSecrets []*managementv1.SharedSecret,
 	projectSecrets []*helper.ProjectProjectSecret,
 ) error {
-	header := []string{
-		"Name",
-		"Namespace",
-		"Project",
-		"Keys",
-		"Age",
-	}
-
-	var values [][]string
+	t := &printer.Table{Header: secretsHeader}
 	for _, secret := range sharedSecrets {
-		var keyNames []string
-		for k := range secret.Spec.Data {
-			keyNames = append(keyNames, k)
-		}
-
-		values = append(values, []string{
+		t.AddRow(secret, secret.Name, []string{
 			secret.Name,
 			secret.Namespace,
 			"",
-			strings.Join(keyNames, ","),
+			strings.Join(secretKeys(secret.Spec.Data), ","),
 			duration.HumanDuration(time.Since(secret.CreationTimestamp.Time)),
 		})
 	}
 
-	for _, secret := range projectSecrets {
-		projectSecret := secret.ProjectSecret
-		var keyNames []string
-		for k := range projectSecret.Spec.Data {
-			keyNames = append(keyNames, k)
-		}
+	addProjectSecrets(t, projectSecrets)
+	return p.Print(t)
+}
 
-		values = append(values, []string{
+func addProjectSecrets(t *printer.Table, projectSecrets []*helper.ProjectProjectSecret) {
+	for _, secret := range projectSecrets {
+		projectSecret := &secret.ProjectSecret
+		t.AddRow(projectSecret, projectSecret.Name, []string{
 			projectSecret.Name,
 			projectSecret.Namespace,
 			secret.Project,
-			strings.Join(keyNames, ","),
+			strings.Join(secretKeys(projectSecret.Spec.Data), ","),
 			duration.HumanDuration(time.Since(projectSecret.CreationTimestamp.Time)),
 		})
 	}
+}
+
+func secretKeys(data map[string][]byte) []string {
+	keyNames := make([]string, 0, len(data))
+	for k := range data {
+		keyNames = append(keyNames, k)
+	}
 
-	table.PrintTable(cmd.log, header, values)
-	return nil
+	sort.Strings(keyNames)
+	return keyNames
 }
@@ -2,6 +2,7 @@ package list
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -11,9 +12,9 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	"github.com/loft-sh/loftctl/v4/pkg/clihelper"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
-	"github.com/loft-sh/log/table"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/util/duration"
 )
@@ -23,6 +24,10 @@ type SpacesCmd struct {
 	*flags.GlobalFlags
 
 	ShowLegacy bool
+	Watch      bool
+	Output     string
+
+	filter instanceFilter
 
 	log log.Logger
 }
@@ -38,6 +43,9 @@ List the loft spaces you have access to
 
 Example:
 loft list spaces
+loft list spaces --watch
+loft list spaces --watch -o json
+loft list spaces --project my-project --phase Sleeping --sort-by age
 ########################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -49,6 +57,7 @@ List the loft spaces you have access to
 
 Example:
 devspace list spaces
+devspace list spaces --watch
 ########################################################
 	`
 	}
@@ -62,38 +71,44 @@ devspace list spaces
 		},
 	}
 	listCmd.Flags().BoolVar(&cmd.ShowLegacy, "show-legacy", false, "If true, will always show the legacy spaces as well")
+	listCmd.Flags().BoolVarP(&cmd.Watch, "watch", "w", false, "If true, will watch the spaces and print changes until interrupted")
+	cmd.filter.AddFlags(listCmd.Flags(), "spaces")
+	printer.AddFlag(listCmd.Flags(), &cmd.Output)
 	return listCmd
 }
 
 // RunSpaces executes the functionality
 func (cmd *SpacesCmd) RunSpaces(ctx context.Context) error {
-	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	p, err := printer.New(cmd.Output, cmd.log)
 	if err != nil {
 		return err
 	}
 
-	header := []string{
-		"Name",
-		"Project",
-		"Cluster",
-		"Sleeping",
-		"Status",
-		"Age",
+	if cmd.Watch && cmd.ShowLegacy {
+		return fmt.Errorf("--watch cannot be used together with --show-legacy")
+	}
+
+	err = cmd.filter.Validate()
+	if err != nil {
+		return err
 	}
-	values := [][]string{}
-	spaceInstances, err := helper.GetSpaceInstances(ctx, baseClient)
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
 	if err != nil {
 		return err
 	}
+	if cmd.Watch {
+		return cmd.watch(ctx, baseClient, p)
+	}
+
+	spaceInstances, err := helper.ListSpaceInstances(ctx, baseClient, cmd.filter.ListOptions())
+	if err != nil {
+		return err
+	}
+
+	rows := []instanceRow{}
 	for _, space := range spaceInstances {
-		values = append(values, []string{
-			clihelper.GetTableDisplayName(space.SpaceInstance.Name, space.SpaceInstance.Spec.DisplayName),
-			space.Project.Name,
-			space.SpaceInstance.Spec.ClusterRef.Cluster,
-			strconv.FormatBool(space.SpaceInstance.Status.Phase == storagev1.InstanceSleeping),
-			string(space.SpaceInstance.Status.Phase),
-			duration.HumanDuration(time.Since(space.SpaceInstance.CreationTimestamp.Time)),
-		})
+		rows = append(rows, spaceInstanceRow(space))
 	}
 	if len(spaceInstances) == 0 || cmd.ShowLegacy {
 		spaces, err := helper.GetSpaces(ctx, baseClient, cmd.log)
@@ -111,17 +126,115 @@ func (cmd *SpacesCmd) RunSpaces(ctx context.Context) error {
 				spaceName = space.Annotations["loft.sh/display-name"] + " (" + spaceName + ")"
 			}
 
-			values = append(values, []string{
-				spaceName,
-				"",
-				space.Cluster,
-				sleeping,
-				string(space.Space.Status.Phase),
-				duration.HumanDuration(time.Since(space.Space.CreationTimestamp.Time)),
+			legacySpace := space.Space
+			rows = append(rows, instanceRow{
+				Row: printer.Row{
+					Object: &legacySpace,
+					Name:   space.Name,
+					Values: []string{
+						spaceName,
+						"",
+						space.Cluster,
+						sleeping,
+						string(space.Space.Status.Phase),
+						duration.HumanDuration(time.Since(space.Space.CreationTimestamp.Time)),
+					},
+					WideValues: []string{"", ""},
+				},
+				Key:     space.Cluster + "/" + space.Name,
+				Cluster: space.Cluster,
+				Phase:   string(space.Space.Status.Phase),
+				Labels:  space.Labels,
+				Created: space.Space.CreationTimestamp.Time,
 			})
 		}
 	}
 
-	table.PrintTable(cmd.log, header, values)
+	t := spacesTable()
+	for _, row := range cmd.filter.Filter(rows) {
+		t.Rows = append(t.Rows, row.Row)
+	}
+
+	return p.Print(t)
+}
+
+// watch prints the space instances and then their changes until the context is done
+func (cmd *SpacesCmd) watch(ctx context.Context, baseClient client.Client, p *printer.Printer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	spaceInstances, events, err := helper.WatchSpaceInstances(ctx, baseClient, cmd.filter.ListOptions())
+	if err != nil {
+		return err
+	}
+
+	rows := []instanceRow{}
+	for _, space := range spaceInstances {
+		rows = append(rows, spaceInstanceRow(space))
+	}
+
+	w := newWatchTable(p, spacesTable(), &cmd.filter)
+	err = w.Init(rows)
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		if event.Err != nil {
+			return event.Err
+		}
+
+		err = w.Update(event.Type, spaceInstanceRow(event.Space))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func spacesTable() *printer.Table {
+	return &printer.Table{
+		Header: []string{
+			"Name",
+			"Project",
+			"Cluster",
+			"Sleeping",
+			"Status",
+			"Age",
+		},
+		WideHeader: []string{
+			"Template",
+			"Owner",
+		},
+	}
+}
+
+func spaceInstanceRow(space *helper.SpaceInstanceProject) instanceRow {
+	spaceInstance := space.SpaceInstance
+	return instanceRow{
+		Row: printer.Row{
+			Object: spaceInstance,
+			Name:   spaceInstance.Name,
+			Values: []string{
+				clihelper.GetTableDisplayName(spaceInstance.Name, spaceInstance.Spec.DisplayName),
+				space.Project.Name,
+				spaceInstance.Spec.ClusterRef.Cluster,
+				strconv.FormatBool(spaceInstance.Status.Phase == storagev1.InstanceSleeping),
+				string(spaceInstance.Status.Phase),
+				duration.HumanDuration(time.Since(spaceInstance.CreationTimestamp.Time)),
+			},
+			WideValues: []string{
+				templateName(spaceInstance.Spec.TemplateRef),
+				ownerName(spaceInstance.Spec.Owner),
+			},
+		},
+		Key:     space.Project.Name + "/" + spaceInstance.Name,
+		Project: space.Project.Name,
+		Cluster: spaceInstance.Spec.ClusterRef.Cluster,
+		Phase:   string(spaceInstance.Status.Phase),
+		Owner:   spaceInstance.Spec.Owner,
+		Labels:  spaceInstance.Labels,
+		Created: spaceInstance.CreationTimestamp.Time,
+	}
+}
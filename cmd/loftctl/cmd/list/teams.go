@@ -8,9 +8,9 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	"github.com/loft-sh/loftctl/v4/pkg/clihelper"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
-	"github.com/loft-sh/log/table"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +19,8 @@ import (
 type TeamsCmd struct {
 	*flags.GlobalFlags
 
+	Output string
+
 	log log.Logger
 }
 
@@ -57,11 +59,17 @@ devspace list teams
 		},
 	}
 
+	printer.AddFlag(clustersCmd.Flags(), &cmd.Output)
 	return clustersCmd
 }
 
 // RunUsers executes the functionality "loft list users"
 func (cmd *TeamsCmd) Run(ctx context.Context) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
 	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
 	if err != nil {
 		return err
@@ -79,16 +87,16 @@ func (cmd *TeamsCmd) Run(ctx context.Context) error {
 		return errors.New("logged in as a team")
 	}
 
-	header := []string{
-		"Name",
+	t := &printer.Table{
+		Header: []string{
+			"Name",
+		},
 	}
-	values := [][]string{}
 	for _, team := range userName.Teams {
-		values = append(values, []string{
+		t.AddRow(team, team.Name, []string{
 			clihelper.DisplayName(team),
 		})
 	}
 
-	table.PrintTable(cmd.log, header, values)
-	return nil
+	return p.Print(t)
 }
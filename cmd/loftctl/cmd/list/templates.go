@@ -0,0 +1,183 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+const (
+	templateTypeSpace          = "space"
+	templateTypeVirtualCluster = "vcluster"
+	templateTypeDevPod         = "devpod"
+)
+
+// TemplatesCmd holds the cmd flags
+type TemplatesCmd struct {
+	*flags.GlobalFlags
+
+	Projects []string
+	Type     string
+	Output   string
+
+	log log.Logger
+}
+
+// NewTemplatesCmd creates a new command
+func NewTemplatesCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &TemplatesCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("list templates", `
+List the space, virtual cluster and DevPod templates that
+can be used in the projects you have access to, together
+with their versions.
+
+Example:
+loft list templates
+loft list templates --project my-project --type vcluster
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+########################################################
+############### devspace list templates ################
+########################################################
+List the space, virtual cluster and DevPod templates that
+can be used in the projects you have access to, together
+with their versions.
+
+Example:
+devspace list templates
+devspace list templates --project my-project --type vcluster
+########################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "templates",
+		Short: "Lists the templates you can use in your projects",
+		Long:  description,
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+
+	c.Flags().StringSliceVar(&cmd.Projects, "project", []string{}, "If set, only lists the templates of the given projects")
+	c.Flags().StringVar(&cmd.Type, "type", "", "If set, only lists templates of the given type. One of: space, vcluster, devpod")
+	printer.AddFlag(c.Flags(), &cmd.Output)
+	return c
+}
+
+// Run executes the functionality
+func (cmd *TemplatesCmd) Run(ctx context.Context) error {
+	switch cmd.Type {
+	case "", templateTypeSpace, templateTypeVirtualCluster, templateTypeDevPod:
+	default:
+		return fmt.Errorf("invalid --type %s, needs to be one of: space, vcluster, devpod", cmd.Type)
+	}
+
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	projects := cmd.Projects
+	if len(projects) == 0 {
+		projectList, err := helper.GetProjects(ctx, managementClient, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, project := range projectList {
+			projects = append(projects, project.Name)
+		}
+	}
+
+	t := &printer.Table{
+		Header: []string{
+			"Template",
+			"Type",
+			"Project",
+			"Latest Version",
+			"Default",
+			"Age",
+		},
+		WideHeader: []string{
+			"Display Name",
+			"Versions",
+		},
+	}
+	for _, project := range projects {
+		templates, err := managementClient.Loft().ManagementV1().Projects().ListTemplates(ctx, project, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("list templates of project %s: %w", project, err)
+		}
+
+		if cmd.Type == "" || cmd.Type == templateTypeSpace {
+			for idx := range templates.SpaceTemplates {
+				template := &templates.SpaceTemplates[idx]
+				addTemplateRow(t, template, templateTypeSpace, project, template.Spec.DisplayName, template.Name == templates.DefaultSpaceTemplate)
+			}
+		}
+		if cmd.Type == "" || cmd.Type == templateTypeVirtualCluster {
+			for idx := range templates.VirtualClusterTemplates {
+				template := &templates.VirtualClusterTemplates[idx]
+				addTemplateRow(t, template, templateTypeVirtualCluster, project, template.Spec.DisplayName, template.Name == templates.DefaultVirtualClusterTemplate)
+			}
+		}
+		if cmd.Type == "" || cmd.Type == templateTypeDevPod {
+			for idx := range templates.DevPodWorkspaceTemplates {
+				template := &templates.DevPodWorkspaceTemplates[idx]
+				addTemplateRow(t, template, templateTypeDevPod, project, template.Spec.DisplayName, template.Name == templates.DefaultDevPodWorkspaceTemplate)
+			}
+		}
+	}
+
+	return p.Print(t)
+}
+
+// versionedTemplate is implemented by the space, virtual cluster and DevPod templates
+type versionedTemplate interface {
+	runtime.Object
+	metav1.Object
+	storagev1.VersionsAccessor
+}
+
+func addTemplateRow(t *printer.Table, template versionedTemplate, templateType, project, displayName string, isDefault bool) {
+	latestVersion, versions := templateVersions(template)
+	t.AddRow(template, template.GetName(), []string{
+		template.GetName(),
+		templateType,
+		project,
+		latestVersion,
+		strconv.FormatBool(isDefault),
+		duration.HumanDuration(time.Since(template.GetCreationTimestamp().Time)),
+	}, displayName, strings.Join(versions, ", "))
+}
@@ -0,0 +1,117 @@
+package list
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// UsersCmd holds the cmd flags
+type UsersCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+
+	log log.Logger
+}
+
+// NewUsersCmd creates a new command
+func NewUsersCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &UsersCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("list users", `
+List the loft users you have access to
+
+Example:
+loft list users
+########################################################
+	`)
+	if upgrade.IsPlugin == "true" {
+		description = `
+########################################################
+################# devspace list users ##################
+########################################################
+List the loft users you have access to
+
+Example:
+devspace list users
+########################################################
+	`
+	}
+	c := &cobra.Command{
+		Use:   "users",
+		Short: product.Replace("Lists the loft users you have access to"),
+		Long:  description,
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+
+	printer.AddFlag(c.Flags(), &cmd.Output)
+	return c
+}
+
+// Run executes the functionality
+func (cmd *UsersCmd) Run(ctx context.Context) error {
+	p, err := printer.New(cmd.Output, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return err
+	}
+
+	userList, err := managementClient.Loft().ManagementV1().Users().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	t := &printer.Table{
+		Header: []string{
+			"Username",
+			"Kubernetes Name",
+			"Display Name",
+			"Email",
+			"Age",
+		},
+		WideHeader: []string{
+			"Disabled",
+		},
+	}
+	for idx := range userList.Items {
+		user := &userList.Items[idx]
+
+		// never print password references
+		user.Spec.PasswordRef = nil
+		user.Spec.CodesRef = nil
+		t.AddRow(user, user.Name, []string{
+			user.Spec.Username,
+			user.Name,
+			user.Spec.DisplayName,
+			user.Spec.Email,
+			duration.HumanDuration(time.Since(user.CreationTimestamp.Time)),
+		}, strconv.FormatBool(user.Spec.Disabled))
+	}
+
+	return p.Print(t)
+}
@@ -0,0 +1,43 @@
+package list
+
+import (
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/loft-sh/loftctl/v4/pkg/version"
+)
+
+// templateName returns the name and version of the referenced template for wide output
+func templateName(templateRef *storagev1.TemplateRef) string {
+	if templateRef == nil || templateRef.Name == "" {
+		return ""
+	} else if templateRef.Version != "" {
+		return templateRef.Name + "@" + templateRef.Version
+	}
+
+	return templateRef.Name
+}
+
+// ownerName returns the owning user or team for wide output
+func ownerName(owner *storagev1.UserOrTeam) string {
+	if owner == nil {
+		return ""
+	} else if owner.Team != "" {
+		return "team/" + owner.Team
+	}
+
+	return owner.User
+}
+
+// templateVersions returns the latest version and all versions of a template or app
+func templateVersions(versions storagev1.VersionsAccessor) (string, []string) {
+	all := []string{}
+	for _, v := range versions.GetVersions() {
+		all = append(all, v.GetVersion())
+	}
+
+	latestVersion := version.GetLatestVersion(versions)
+	if latestVersion == nil {
+		return "", all
+	}
+
+	return latestVersion.GetVersion(), all
+}
@@ -2,6 +2,7 @@ package list
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/loft-sh/api/v4/pkg/product"
@@ -9,9 +10,9 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	"github.com/loft-sh/loftctl/v4/pkg/clihelper"
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
-	"github.com/loft-sh/log/table"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/util/duration"
 )
@@ -21,7 +22,12 @@ type VirtualClustersCmd struct {
 	*flags.GlobalFlags
 
 	ShowLegacy bool
-	log        log.Logger
+	Watch      bool
+	Output     string
+
+	filter instanceFilter
+
+	log log.Logger
 }
 
 // NewVirtualClustersCmd creates a new command
@@ -35,6 +41,9 @@ List the loft virtual clusters you have access to
 
 Example:
 loft list vclusters
+loft list vclusters --watch
+loft list vclusters --watch -o json
+loft list vclusters --project my-project -l team=backend --sort-by name
 ########################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -46,6 +55,7 @@ List the loft virtual clusters you have access to
 
 Example:
 devspace list vclusters
+devspace list vclusters --watch
 ########################################################
 	`
 	}
@@ -59,40 +69,44 @@ devspace list vclusters
 		},
 	}
 	listCmd.Flags().BoolVar(&cmd.ShowLegacy, "show-legacy", false, "If true, will always show the legacy virtual clusters as well")
+	listCmd.Flags().BoolVarP(&cmd.Watch, "watch", "w", false, "If true, will watch the virtual clusters and print changes until interrupted")
+	cmd.filter.AddFlags(listCmd.Flags(), "virtual clusters")
+	printer.AddFlag(listCmd.Flags(), &cmd.Output)
 	return listCmd
 }
 
 // Run executes the functionality
 func (cmd *VirtualClustersCmd) Run(ctx context.Context) error {
-	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	p, err := printer.New(cmd.Output, cmd.log)
 	if err != nil {
 		return err
 	}
 
-	header := []string{
-		"Name",
-		"Project",
-		"Cluster",
-		"Namespace",
-		"Status",
-		"Age",
+	if cmd.Watch && cmd.ShowLegacy {
+		return fmt.Errorf("--watch cannot be used together with --show-legacy")
+	}
+
+	err = cmd.filter.Validate()
+	if err != nil {
+		return err
 	}
-	values := [][]string{}
 
-	virtualClusterInstances, err := helper.GetVirtualClusterInstances(ctx, baseClient)
+	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
 	if err != nil {
 		return err
 	}
+	if cmd.Watch {
+		return cmd.watch(ctx, baseClient, p)
+	}
 
+	virtualClusterInstances, err := helper.ListVirtualClusterInstances(ctx, baseClient, cmd.filter.ListOptions())
+	if err != nil {
+		return err
+	}
+
+	rows := []instanceRow{}
 	for _, virtualCluster := range virtualClusterInstances {
-		values = append(values, []string{
-			clihelper.GetTableDisplayName(virtualCluster.VirtualCluster.Name, virtualCluster.VirtualCluster.Spec.DisplayName),
-			virtualCluster.Project.Name,
-			virtualCluster.VirtualCluster.Spec.ClusterRef.Cluster,
-			virtualCluster.VirtualCluster.Spec.ClusterRef.Namespace,
-			string(virtualCluster.VirtualCluster.Status.Phase),
-			duration.HumanDuration(time.Since(virtualCluster.VirtualCluster.CreationTimestamp.Time)),
-		})
+		rows = append(rows, virtualClusterInstanceRow(virtualCluster))
 	}
 	if len(virtualClusterInstances) == 0 || cmd.ShowLegacy {
 		virtualClusters, err := helper.GetVirtualClusters(ctx, baseClient, cmd.log)
@@ -109,17 +123,115 @@ func (cmd *VirtualClustersCmd) Run(ctx context.Context) error {
 				vClusterName = virtualCluster.VirtualCluster.Annotations["loft.sh/display-name"] + " (" + vClusterName + ")"
 			}
 
-			values = append(values, []string{
-				vClusterName,
-				"",
-				virtualCluster.Cluster,
-				virtualCluster.VirtualCluster.Namespace,
-				status,
-				duration.HumanDuration(time.Since(virtualCluster.VirtualCluster.CreationTimestamp.Time)),
+			legacyVirtualCluster := virtualCluster.VirtualCluster
+			rows = append(rows, instanceRow{
+				Row: printer.Row{
+					Object: &legacyVirtualCluster,
+					Name:   virtualCluster.VirtualCluster.Name,
+					Values: []string{
+						vClusterName,
+						"",
+						virtualCluster.Cluster,
+						virtualCluster.VirtualCluster.Namespace,
+						status,
+						duration.HumanDuration(time.Since(virtualCluster.VirtualCluster.CreationTimestamp.Time)),
+					},
+					WideValues: []string{"", ""},
+				},
+				Key:     virtualCluster.Cluster + "/" + virtualCluster.VirtualCluster.Namespace + "/" + virtualCluster.VirtualCluster.Name,
+				Cluster: virtualCluster.Cluster,
+				Phase:   status,
+				Labels:  virtualCluster.VirtualCluster.Labels,
+				Created: virtualCluster.VirtualCluster.CreationTimestamp.Time,
 			})
 		}
 	}
 
-	table.PrintTable(cmd.log, header, values)
+	t := virtualClustersTable()
+	for _, row := range cmd.filter.Filter(rows) {
+		t.Rows = append(t.Rows, row.Row)
+	}
+
+	return p.Print(t)
+}
+
+// watch prints the virtual cluster instances and then their changes until the context is done
+func (cmd *VirtualClustersCmd) watch(ctx context.Context, baseClient client.Client, p *printer.Printer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	virtualClusterInstances, events, err := helper.WatchVirtualClusterInstances(ctx, baseClient, cmd.filter.ListOptions())
+	if err != nil {
+		return err
+	}
+
+	rows := []instanceRow{}
+	for _, virtualCluster := range virtualClusterInstances {
+		rows = append(rows, virtualClusterInstanceRow(virtualCluster))
+	}
+
+	w := newWatchTable(p, virtualClustersTable(), &cmd.filter)
+	err = w.Init(rows)
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		if event.Err != nil {
+			return event.Err
+		}
+
+		err = w.Update(event.Type, virtualClusterInstanceRow(event.VirtualCluster))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+func virtualClustersTable() *printer.Table {
+	return &printer.Table{
+		Header: []string{
+			"Name",
+			"Project",
+			"Cluster",
+			"Namespace",
+			"Status",
+			"Age",
+		},
+		WideHeader: []string{
+			"Template",
+			"Owner",
+		},
+	}
+}
+
+func virtualClusterInstanceRow(virtualCluster *helper.VirtualClusterInstanceProject) instanceRow {
+	virtualClusterInstance := virtualCluster.VirtualCluster
+	return instanceRow{
+		Row: printer.Row{
+			Object: virtualClusterInstance,
+			Name:   virtualClusterInstance.Name,
+			Values: []string{
+				clihelper.GetTableDisplayName(virtualClusterInstance.Name, virtualClusterInstance.Spec.DisplayName),
+				virtualCluster.Project.Name,
+				virtualClusterInstance.Spec.ClusterRef.Cluster,
+				virtualClusterInstance.Spec.ClusterRef.Namespace,
+				string(virtualClusterInstance.Status.Phase),
+				duration.HumanDuration(time.Since(virtualClusterInstance.CreationTimestamp.Time)),
+			},
+			WideValues: []string{
+				templateName(virtualClusterInstance.Spec.TemplateRef),
+				ownerName(virtualClusterInstance.Spec.Owner),
+			},
+		},
+		Key:     virtualCluster.Project.Name + "/" + virtualClusterInstance.Name,
+		Project: virtualCluster.Project.Name,
+		Cluster: virtualClusterInstance.Spec.ClusterRef.Cluster,
+		Phase:   string(virtualClusterInstance.Status.Phase),
+		Owner:   virtualClusterInstance.Spec.Owner,
+		Labels:  virtualClusterInstance.Labels,
+		Created: virtualClusterInstance.CreationTimestamp.Time,
+	}
+}
@@ -0,0 +1,112 @@
+package list
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/loft-sh/loftctl/v4/pkg/printer"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/kubectl/pkg/util/term"
+)
+
+// clearScreen moves the cursor to the top left and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// watchTable keeps the latest row of every watched object that matches the filter. Table
+// output is redrawn on every change, stream output prints only the changed object.
+type watchTable struct {
+	printer *printer.Printer
+	table   *printer.Table
+	filter  *instanceFilter
+	rows    map[string]instanceRow
+}
+
+func newWatchTable(p *printer.Printer, table *printer.Table, filter *instanceFilter) *watchTable {
+	return &watchTable{
+		printer: p,
+		table:   table,
+		filter:  filter,
+		rows:    map[string]instanceRow{},
+	}
+}
+
+// Init prints the rows of the initial list
+func (w *watchTable) Init(rows []instanceRow) error {
+	for _, row := range rows {
+		if w.filter.Matches(row) {
+			w.rows[row.Key] = row
+		}
+	}
+	if !w.printer.IsStream() {
+		return w.redraw()
+	}
+
+	for _, row := range w.sorted() {
+		err := w.printer.PrintEvent(string(watch.Added), row.Row)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Update applies a watch event to the table and prints it. Rows that no longer match the
+// filter are removed as if they were deleted.
+func (w *watchTable) Update(eventType watch.EventType, row instanceRow) error {
+	if eventType == watch.Deleted || !w.filter.Matches(row) {
+		if _, ok := w.rows[row.Key]; !ok {
+			return nil
+		}
+
+		eventType = watch.Deleted
+		delete(w.rows, row.Key)
+	} else {
+		w.rows[row.Key] = row
+	}
+	if !w.printer.IsStream() {
+		return w.redraw()
+	}
+
+	return w.printer.PrintEvent(string(eventType), row.Row)
+}
+
+// sorted returns the rows ordered by the sort field of the filter or by key
+func (w *watchTable) sorted() []instanceRow {
+	rows := make([]instanceRow, 0, len(w.rows))
+	for _, row := range w.rows {
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Key < rows[j].Key
+	})
+
+	w.filter.Sort(rows)
+	return rows
+}
+
+func (w *watchTable) redraw() error {
+	t := &printer.Table{
+		Header:     w.table.Header,
+		WideHeader: w.table.WideHeader,
+	}
+	for _, row := range w.sorted() {
+		t.Rows = append(t.Rows, row.Row)
+	}
+
+	// redraw in place on terminals and separate the tables otherwise
+	if term.IsTerminal(os.Stdout) {
+		_, err := fmt.Fprint(os.Stdout, clearScreen)
+		if err != nil {
+			return err
+		}
+	} else {
+		_, err := fmt.Fprintln(os.Stdout)
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.printer.Print(t)
+}
@@ -3,8 +3,10 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	dockerconfig "github.com/docker/cli/cli/config"
@@ -16,6 +18,7 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	"github.com/loft-sh/loftctl/v4/pkg/clihelper"
+	"github.com/loft-sh/loftctl/v4/pkg/credentials"
 	"github.com/loft-sh/loftctl/v4/pkg/docker"
 	"github.com/loft-sh/loftctl/v4/pkg/kube"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
@@ -36,6 +39,14 @@ type LoginCmd struct {
 	AccessKey   string
 	Insecure    bool
 	DockerLogin bool
+
+	CredentialStore string
+	Headless        bool
+	CallbackPort    int
+	ValidateState   bool
+
+	CertificateAuthority     string
+	CertificateAuthorityData string
 }
 
 // NewLoginCmd creates a new open command
@@ -46,11 +57,16 @@ func NewLoginCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
 	}
 
 	description := product.ReplaceWithHeader("login", `
-Login into loft
+Login into loft. Without a browser on this machine, e.g. over
+ssh, use --headless to complete the login on another device
 
 Example:
 loft login https://my-loft.com
 loft login https://my-loft.com --access-key myaccesskey
+loft login https://staging.my-loft.com --profile staging
+loft login https://my-loft.com --credential-store keyring
+loft login https://my-loft.com --headless
+loft login https://my-loft.com --certificate-authority ca.crt
 ########################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -75,6 +91,11 @@ devspace login https://my-loft.com --access-key myaccesskey
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			// Check for newer version
 			upgrade.PrintNewerVersionWarning()
+			// a custom certificate authority implies verifying the certificate
+			if (cmd.CertificateAuthority != "" || cmd.CertificateAuthorityData != "") && !cobraCmd.Flags().Changed("insecure") {
+				cmd.Insecure = false
+			}
+
 			// project prefix initializatin not necessary
 			return cmd.RunLogin(cobraCmd.Context(), args)
 		},
@@ -83,6 +104,12 @@ devspace login https://my-loft.com --access-key myaccesskey
 	loginCmd.Flags().StringVar(&cmd.AccessKey, "access-key", "", "The access key to use")
 	loginCmd.Flags().BoolVar(&cmd.Insecure, "insecure", true, product.Replace("Allow login into an insecure Loft instance"))
 	loginCmd.Flags().BoolVar(&cmd.DockerLogin, "docker-login", true, "If true, will log into the docker image registries the user has image pull secrets for")
+	loginCmd.Flags().StringVar(&cmd.CertificateAuthority, "certificate-authority", "", "Path to a PEM encoded certificate authority bundle to verify the loft instance with")
+	loginCmd.Flags().StringVar(&cmd.CertificateAuthorityData, "certificate-authority-data", "", "Base64 encoded PEM certificate authority bundle to verify the loft instance with")
+	loginCmd.Flags().BoolVar(&cmd.Headless, "headless", false, "If true, prints a url and a code to complete the login on another device instead of opening a browser, e.g. when logging in over ssh")
+	loginCmd.Flags().IntVar(&cmd.CallbackPort, "callback-port", client.DefaultCallbackPort, "The local port the browser redirects to after the login. If 0, a random free port is used")
+	loginCmd.Flags().BoolVar(&cmd.ValidateState, "validate-state", true, "If true, rejects login callbacks without the random state sent to the login page. Only disable this for loft versions whose login page doesn't return the state")
+	loginCmd.Flags().StringVar(&cmd.CredentialStore, "credential-store", "", "Where to store the access keys of the config. Can be either plaintext, keyring (linux and BSD only) or file. The file store reads its passphrase from the "+credentials.PassphraseEnv+" environment variable")
 	return loginCmd
 }
 
@@ -125,15 +152,48 @@ func (cmd *LoginCmd) RunLogin(ctx context.Context, args []string) error {
 		url = "https://" + url
 	}
 
-	// log into loft
+	// configure how the loft instance is verified
 	url = strings.TrimSuffix(url, "/")
+	certificateAuthorityChanged, err := cmd.applyCertificateAuthority(loader.Config(), url)
+	if err != nil {
+		return err
+	}
+
+	// switch the credential store, the secrets are moved on the next save
+	if cmd.CredentialStore != "" {
+		err = credentials.Validate(cmd.CredentialStore)
+		if err != nil {
+			return err
+		}
+
+		loader.Config().CredentialStore = cmd.CredentialStore
+	}
+
+	// log into loft
 	if cmd.AccessKey != "" {
 		err = loader.LoginWithAccessKey(url, cmd.AccessKey, cmd.Insecure)
 	} else {
-		err = loader.Login(url, cmd.Insecure, cmd.Log)
+		err = loader.LoginWithOptions(ctx, url, cmd.Insecure, client.LoginOptions{
+			Headless:      cmd.Headless,
+			CallbackPort:  cmd.CallbackPort,
+			ValidateState: cmd.ValidateState,
+		}, cmd.Log)
 	}
 	if err != nil {
 		return err
+	} else if cmd.CredentialStore != "" || certificateAuthorityChanged {
+		err = loader.Save()
+		if err != nil {
+			return errors.Wrap(err, "save config")
+		}
+	}
+
+	// make the profile the current one
+	if cmd.Profile != "" {
+		err = client.SwitchProfile(cmd.Config, cmd.Profile)
+		if err != nil {
+			return errors.Wrap(err, "switch profile")
+		}
 	}
 	cmd.Log.Donef(product.Replace("Successfully logged into Loft instance %s"), ansi.Color(url, "white+b"))
 
@@ -150,6 +210,50 @@ func (cmd *LoginCmd) RunLogin(ctx context.Context, args []string) error {
 	return nil
 }
 
+// applyCertificateAuthority stores the certificate authority flags in the config. The
+// certificate authority of a previous login is dropped when logging into another host.
+func (cmd *LoginCmd) applyCertificateAuthority(config *client.Config, url string) (bool, error) {
+	if cmd.CertificateAuthority == "" && cmd.CertificateAuthorityData == "" {
+		if config.Host == url || (config.CertificateAuthority == "" && len(config.CertificateAuthorityData) == 0) {
+			return false, nil
+		}
+
+		config.CertificateAuthority = ""
+		config.CertificateAuthorityData = nil
+		return true, nil
+	} else if cmd.Insecure {
+		return false, fmt.Errorf("--insecure cannot be combined with --certificate-authority or --certificate-authority-data")
+	}
+
+	tlsOptions := client.TLSOptions{}
+	if cmd.CertificateAuthority != "" {
+		path, err := filepath.Abs(cmd.CertificateAuthority)
+		if err != nil {
+			return false, err
+		}
+
+		tlsOptions.CertificateAuthority = path
+	}
+	if cmd.CertificateAuthorityData != "" {
+		data, err := base64.StdEncoding.DecodeString(cmd.CertificateAuthorityData)
+		if err != nil {
+			return false, fmt.Errorf("decode certificate authority data: %w", err)
+		}
+
+		tlsOptions.CertificateAuthorityData = data
+	}
+
+	// make sure the bundle is valid before logging in
+	_, err := tlsOptions.TLSConfig()
+	if err != nil {
+		return false, err
+	}
+
+	config.CertificateAuthority = tlsOptions.CertificateAuthority
+	config.CertificateAuthorityData = tlsOptions.CertificateAuthorityData
+	return true, nil
+}
+
 func (cmd *LoginCmd) printLoginDetails(ctx context.Context, loader client.Client, config *client.Config) error {
 	if config.Host == "" {
 		cmd.Log.Info("Not logged in")
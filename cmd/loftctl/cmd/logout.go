@@ -7,6 +7,7 @@ import (
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/tokencache"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
 	"github.com/mgutz/ansi"
@@ -89,6 +90,11 @@ func (cmd *LogoutCmd) RunLogout(ctx context.Context, args []string) error {
 			return fmt.Errorf("save config: %w", err)
 		}
 
+		err = tokencache.New(tokenCacheDir()).Clear()
+		if err != nil {
+			cmd.Log.Debugf("error clearing token cache: %v", err)
+		}
+
 		cmd.Log.Donef(product.Replace("Successfully logged out of loft instance %s"), ansi.Color(configHost, "white+b"))
 	}
 
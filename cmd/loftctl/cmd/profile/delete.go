@@ -0,0 +1,77 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	pdefaults "github.com/loft-sh/loftctl/v4/pkg/defaults"
+	"github.com/loft-sh/log"
+	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+)
+
+// DeleteCmd holds the cmd flags
+type DeleteCmd struct {
+	*flags.GlobalFlags
+
+	log log.Logger
+}
+
+// NewDeleteCmd creates a new command
+func NewDeleteCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &DeleteCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("profile delete", `
+Deletes a login profile and its defaults. Deleting the
+current profile logs the cli out.
+
+Example:
+loft profile delete staging
+########################################################
+	`)
+	deleteCmd := &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Deletes a login profile",
+		Long:  description,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(args[0])
+		},
+	}
+
+	return deleteCmd
+}
+
+// Run executes the functionality
+func (cmd *DeleteCmd) Run(name string) error {
+	config, err := client.LoadConfig(cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	err = config.DeleteProfile(name)
+	if err != nil {
+		return err
+	}
+
+	err = client.SaveConfig(cmd.Config, config)
+	if err != nil {
+		return err
+	}
+
+	// the default profile shares the defaults file with configs without profiles
+	if name != client.DefaultProfile {
+		err = os.Remove(filepath.Join(pdefaults.ConfigFolder, pdefaults.ProfileFile(name)))
+		if err != nil && !os.IsNotExist(err) {
+			cmd.log.Warnf("Error deleting defaults of profile %s: %v", name, err)
+		}
+	}
+
+	cmd.log.Donef("Deleted profile %s", ansi.Color(name, "white+b"))
+	return nil
+}
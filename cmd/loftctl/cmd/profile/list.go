@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/log/table"
+	"github.com/spf13/cobra"
+)
+
+// ListCmd holds the cmd flags
+type ListCmd struct {
+	*flags.GlobalFlags
+
+	log log.Logger
+}
+
+// NewListCmd creates a new command
+func NewListCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ListCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("profile list", `
+List all login profiles of the loft config
+
+Example:
+loft profile list
+########################################################
+	`)
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists all login profiles",
+		Long:  description,
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run()
+		},
+	}
+
+	return listCmd
+}
+
+// Run executes the functionality
+func (cmd *ListCmd) Run() error {
+	config, err := client.LoadConfig(cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	header := []string{
+		"Profile",
+		"Host",
+		"Logged In",
+		"Current",
+	}
+	values := [][]string{}
+	for _, name := range config.ProfileNames() {
+		profile := config.Profiles[name]
+		loggedIn := "false"
+		if profile.AccessKey != "" {
+			loggedIn = "true"
+		}
+		current := ""
+		if name == config.CurrentProfile {
+			current = "*"
+		}
+
+		values = append(values, []string{
+			name,
+			profile.Host,
+			loggedIn,
+			current,
+		})
+	}
+
+	table.PrintTable(cmd.log, header, values)
+	return nil
+}
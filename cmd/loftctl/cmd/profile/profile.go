@@ -0,0 +1,32 @@
+package profile
+
+import (
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/spf13/cobra"
+)
+
+// NewProfileCmd creates a new command
+func NewProfileCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	description := product.ReplaceWithHeader("profile", `
+Manages the login profiles of the loft config
+
+Example:
+loft login https://staging.my-loft.com --profile staging
+loft profile list
+loft profile use staging
+########################################################
+	`)
+
+	profileCmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manages the login profiles",
+		Long:  description,
+		Args:  cobra.NoArgs,
+	}
+
+	profileCmd.AddCommand(NewListCmd(globalFlags))
+	profileCmd.AddCommand(NewUseCmd(globalFlags))
+	profileCmd.AddCommand(NewDeleteCmd(globalFlags))
+	return profileCmd
+}
@@ -0,0 +1,55 @@
+package profile
+
+import (
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/log"
+	"github.com/mgutz/ansi"
+	"github.com/spf13/cobra"
+)
+
+// UseCmd holds the cmd flags
+type UseCmd struct {
+	*flags.GlobalFlags
+
+	log log.Logger
+}
+
+// NewUseCmd creates a new command
+func NewUseCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &UseCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+	description := product.ReplaceWithHeader("profile use", `
+Switches the current login profile. Kube contexts that
+were created with another profile keep using it.
+
+Example:
+loft profile use staging
+########################################################
+	`)
+	useCmd := &cobra.Command{
+		Use:   "use [name]",
+		Short: "Switches the current login profile",
+		Long:  description,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(args[0])
+		},
+	}
+
+	return useCmd
+}
+
+// Run executes the functionality
+func (cmd *UseCmd) Run(name string) error {
+	err := client.SwitchProfile(cmd.Config, name)
+	if err != nil {
+		return err
+	}
+
+	cmd.log.Donef("Switched to profile %s", ansi.Color(name, "white+b"))
+	return nil
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/backup"
+	loftclient "github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/log"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RestoreCmd holds the cmd flags
+type RestoreCmd struct {
+	*flags.GlobalFlags
+	Log       log.Logger
+	Namespace string
+	Filename  string
+	DryRun    bool
+	Conflict  string
+
+	PassphraseFile string
+	PrivateKey     string
+}
+
+// NewRestoreCmd creates a new command
+func NewRestoreCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RestoreCmd{
+		GlobalFlags: globalFlags,
+		Log:         log.GetInstance(),
+	}
+
+	description := product.ReplaceWithHeader("restore", `
+Restore applies a backup created by 'loft backup' to the
+Loft management plane
+
+Example:
+loft restore
+loft restore --filename backup.yaml --conflict overwrite
+loft restore --filename backup.tar.gz
+loft restore --filename backup.yaml --passphrase-file passphrase.txt
+########################################################
+	`)
+
+	c := &cobra.Command{
+		Use:   "restore",
+		Short: product.Replace("Restore a loft management plane backup"),
+		Long:  description,
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			// we need to set the project namespace prefix correctly here
+			_, err := loftclient.InitClientFromPath(cobraCmd.Context(), cmd.Config)
+			if err != nil {
+				return fmt.Errorf("create loft client: %w", err)
+			}
+
+			return cmd.Run(cobraCmd, args)
+		},
+	}
+
+	c.Flags().StringVar(&cmd.Namespace, "namespace", "loft", product.Replace("The namespace to loft was installed into"))
+	c.Flags().StringVar(&cmd.Filename, "filename", "backup.yaml", "The filename to read the backup from")
+	c.Flags().BoolVar(&cmd.DryRun, "dry-run", false, "If enabled, objects are only validated by the server and not persisted")
+	c.Flags().StringVar(&cmd.Conflict, "conflict", string(backup.ConflictPolicySkip), "What to do if an object already exists. Valid options are: skip, overwrite and fail")
+	c.Flags().StringVar(&cmd.PassphraseFile, "passphrase-file", "", "The file to read the passphrase of an encrypted backup from. Can also be set via the "+backupPassphraseEnv+" environment variable")
+	c.Flags().StringVar(&cmd.PrivateKey, "private-key", "", "Path to a PEM encoded RSA private key to decrypt a backup that was encrypted for a public key")
+	return c
+}
+
+// Run executes the functionality
+func (cmd *RestoreCmd) Run(cobraCmd *cobra.Command, args []string) error {
+	conflictPolicy := backup.ConflictPolicy(cmd.Conflict)
+	if conflictPolicy != backup.ConflictPolicySkip && conflictPolicy != backup.ConflictPolicyOverwrite && conflictPolicy != backup.ConflictPolicyFail {
+		return fmt.Errorf("unrecognized conflict policy %s, needs to be either skip, overwrite or fail", cmd.Conflict)
+	}
+
+	reader := &backupReader{
+		PassphraseFile: cmd.PassphraseFile,
+		PrivateKey:     cmd.PrivateKey,
+		Log:            cmd.Log,
+	}
+	objects, manifest, err := reader.Read(cmd.Filename)
+	if err != nil {
+		return err
+	} else if manifest != nil {
+		cmd.Log.Infof("Backup was created at %s with cli version %s", manifest.Created.Format(time.RFC3339), manifest.CLIVersion)
+	}
+
+	ctx := cobraCmd.Context()
+	client, err := newHostClusterClient(ctx, cmd.Namespace, cmd.Log)
+	if err != nil || client == nil {
+		return err
+	}
+
+	if cmd.DryRun {
+		cmd.Log.Infof("Restoring %d objects from %s (dry run)...", len(objects), cmd.Filename)
+	} else {
+		cmd.Log.Infof("Restoring %d objects from %s...", len(objects), cmd.Filename)
+	}
+	errors := backup.Restore(ctx, client, objects, backup.RestoreOptions{
+		DryRun:         cmd.DryRun,
+		ConflictPolicy: conflictPolicy,
+	}, func(msg string) {
+		cmd.Log.Info(msg)
+	})
+	for _, err := range errors {
+		cmd.Log.Warn(err)
+	}
+	if len(errors) > 0 {
+		return fmt.Errorf("restore finished with %d error(s)", len(errors))
+	}
+
+	cmd.Log.Donef("Restored backup from %s", cmd.Filename)
+	return nil
+}
+
+// backupReader reads yaml and archive backups and decrypts them if needed
+type backupReader struct {
+	PassphraseFile string
+	PrivateKey     string
+	Log            log.Logger
+
+	decrypter *backup.Decrypter
+}
+
+// Read parses the given backup file. The manifest is only returned for archive backups.
+func (r *backupReader) Read(filename string) ([]runtime.Object, *backup.Manifest, error) {
+	backupBytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if backup.IsEncryptedFile(backupBytes) {
+		decrypter, err := r.getDecrypter()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		backupBytes, err = decrypter.DecryptFile(backupBytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decrypt backup %s: %w", filename, err)
+		}
+	}
+
+	var objects []runtime.Object
+	var manifest *backup.Manifest
+	if backup.IsArchive(backupBytes) {
+		objects, manifest, err = backup.FromArchive(scheme, backupBytes)
+	} else {
+		objects, err = backup.FromYAML(scheme, backupBytes)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse backup %s: %w", filename, err)
+	}
+
+	if backup.HasEncryptedSecrets(objects) {
+		decrypter, err := r.getDecrypter()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = decrypter.DecryptSecrets(objects)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decrypt backup %s: %w", filename, err)
+		}
+	}
+
+	return objects, manifest, nil
+}
+
+func (r *backupReader) getDecrypter() (*backup.Decrypter, error) {
+	if r.decrypter != nil {
+		return r.decrypter, nil
+	}
+
+	decrypter, err := newBackupDecrypter(r.PassphraseFile, r.PrivateKey, r.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	r.decrypter = decrypter
+	return r.decrypter, nil
+}
+
+// newBackupDecrypter creates a decrypter from the given private key or passphrase
+func newBackupDecrypter(passphraseFile, privateKey string, log log.Logger) (*backup.Decrypter, error) {
+	var err error
+	key := backup.EncryptionKey{}
+	if privateKey != "" {
+		key.PrivateKey, err = backup.LoadPrivateKey(privateKey)
+	} else {
+		key.Passphrase, err = backupPassphrase(passphraseFile, log)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return backup.NewDecrypter(key), nil
+}
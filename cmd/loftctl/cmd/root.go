@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/connect"
@@ -16,6 +19,7 @@ import (
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/get"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/importcmd"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/list"
+	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/profile"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/reset"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/set"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/cmd/share"
@@ -26,10 +30,12 @@ import (
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/defaults"
+	"github.com/loft-sh/loftctl/v4/pkg/httputil"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 )
 
 // NewRootCmd returns a new root command
@@ -50,6 +56,16 @@ func NewRootCmd(streamLogger *log.StreamLogger) *cobra.Command {
 			if (globalFlags.Config == "" || globalFlags.Config == client.DefaultCacheConfig) && os.Getenv("LOFT_CONFIG") != "" {
 				globalFlags.Config = os.Getenv("LOFT_CONFIG")
 			}
+			if globalFlags.Profile == "" && os.Getenv("LOFT_PROFILE") != "" {
+				globalFlags.Profile = os.Getenv("LOFT_PROFILE")
+			}
+			if globalFlags.Profile != "" {
+				err := client.ValidateProfileName(globalFlags.Profile)
+				if err != nil {
+					return err
+				}
+			}
+			client.SetProfile(globalFlags.Profile)
 
 			if globalFlags.LogOutput == "json" {
 				streamLogger.SetFormat(log.JSONFormat)
@@ -59,6 +75,7 @@ func NewRootCmd(streamLogger *log.StreamLogger) *cobra.Command {
 				return fmt.Errorf("unrecognized log format %s, needs to be either plain or json", globalFlags.LogOutput)
 			}
 
+			setupTrace(globalFlags)
 			return nil
 		},
 		Long: product.Replace(`Loft CLI`) + " - www.loft.sh",
@@ -67,6 +84,60 @@ func NewRootCmd(streamLogger *log.StreamLogger) *cobra.Command {
 
 var globalFlags *flags.GlobalFlags
 
+// setupTrace enables request tracing if requested via flags or environment. Traces are
+// written to stderr, so they don't interfere with the output of commands such as token.
+func setupTrace(globalFlags *flags.GlobalFlags) {
+	if !globalFlags.Trace {
+		globalFlags.Trace, _ = strconv.ParseBool(os.Getenv("LOFT_TRACE"))
+	}
+	if globalFlags.TraceHAR == "" {
+		globalFlags.TraceHAR = os.Getenv("LOFT_TRACE_HAR")
+	}
+	if !globalFlags.Trace && globalFlags.TraceHAR == "" {
+		client.SetTracer(nil)
+		return
+	}
+
+	traceLogger := log.NewStreamLogger(os.Stderr, os.Stderr, logrus.DebugLevel)
+	if globalFlags.LogOutput == "json" {
+		traceLogger.SetFormat(log.JSONFormat)
+	}
+
+	tracer := httputil.NewTracer(nil, globalFlags.TraceBodies, globalFlags.TraceHAR != "")
+	tracer.Version = upgrade.GetVersion()
+	if globalFlags.Trace {
+		tracer.Logf = traceLogger.Debugf
+	}
+	client.SetTracer(tracer)
+}
+
+// profileFromArgs returns the profile that --profile, --config and their environment variables
+// select in the given arguments. Other flags are ignored.
+func profileFromArgs(args []string) (string, error) {
+	flagSet := flag.NewFlagSet("profile", flag.ContinueOnError)
+	flagSet.ParseErrorsWhitelist.UnknownFlags = true
+	flagSet.SetOutput(io.Discard)
+	flagSet.Usage = func() {}
+	config := flagSet.String("config", client.DefaultCacheConfig, "")
+	profile := flagSet.String("profile", "", "")
+	err := flagSet.Parse(args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		return "", err
+	}
+
+	if (*config == "" || *config == client.DefaultCacheConfig) && os.Getenv("LOFT_CONFIG") != "" {
+		*config = os.Getenv("LOFT_CONFIG")
+	}
+	if *profile == "" {
+		*profile = os.Getenv("LOFT_PROFILE")
+	}
+	if *profile != "" {
+		return *profile, nil
+	}
+
+	return client.ActiveProfile(*config)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -78,6 +149,11 @@ func Execute() {
 
 	// Execute command
 	err := rootCmd.ExecuteContext(context.Background())
+	if tracer := client.Tracer(); tracer != nil && globalFlags.TraceHAR != "" {
+		if harErr := tracer.WriteHAR(globalFlags.TraceHAR); harErr != nil {
+			log.Warnf("Error writing HAR file %s: %v", globalFlags.TraceHAR, harErr)
+		}
+	}
 	if err != nil {
 		if globalFlags.Debug {
 			log.Fatalf("%+v", err)
@@ -97,6 +173,15 @@ func BuildRoot(log *log.StreamLogger) *cobra.Command {
 		log.Debugf("Error loading defaults: %v", err)
 	}
 
+	// defaults are stored per profile. Commands read their defaults while they are built, so the
+	// profile is resolved from the arguments before the flags are parsed.
+	profileName, err := profileFromArgs(os.Args[1:])
+	if err != nil {
+		log.Debugf("Error resolving profile: %v", err)
+	} else if err := defaults.SetProfile(profileName); err != nil {
+		log.Debugf("Error loading defaults: %v", err)
+	}
+
 	// add top level commands
 	rootCmd.AddCommand(NewStartCmd(globalFlags))
 	rootCmd.AddCommand(NewLoginCmd(globalFlags))
@@ -104,6 +189,7 @@ func BuildRoot(log *log.StreamLogger) *cobra.Command {
 	rootCmd.AddCommand(NewUiCmd(globalFlags))
 	rootCmd.AddCommand(NewTokenCmd(globalFlags))
 	rootCmd.AddCommand(NewBackupCmd(globalFlags))
+	rootCmd.AddCommand(NewRestoreCmd(globalFlags))
 	rootCmd.AddCommand(NewCompletionCmd(rootCmd, globalFlags))
 	rootCmd.AddCommand(NewUpgradeCmd())
 
@@ -123,6 +209,7 @@ func BuildRoot(log *log.StreamLogger) *cobra.Command {
 	rootCmd.AddCommand(importcmd.NewImportCmd(globalFlags))
 	rootCmd.AddCommand(connect.NewConnectCmd(globalFlags))
 	rootCmd.AddCommand(cmddefaults.NewDefaultsCmd(globalFlags, defaults))
+	rootCmd.AddCommand(profile.NewProfileCmd(globalFlags))
 	rootCmd.AddCommand(devpod.NewDevPodCmd(globalFlags))
 	rootCmd.AddCommand(credits.NewCreditsCmd())
 
@@ -10,6 +10,7 @@ import (
 	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/bulk"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	"github.com/loft-sh/loftctl/v4/pkg/config"
@@ -31,6 +32,7 @@ type SpaceCmd struct {
 	Project       string
 	Cluster       string
 	ForceDuration int64
+	Bulk          bulk.Flags
 
 	Log log.Logger
 }
@@ -48,6 +50,8 @@ Sleep puts a space to sleep
 Example:
 loft sleep space myspace
 loft sleep space myspace --project myproject
+loft sleep space preview-1 preview-2 --project myproject
+loft sleep space --project myproject --selector env=preview
 #######################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -60,15 +64,17 @@ Sleep puts a space to sleep
 Example:
 devspace sleep space myspace
 devspace sleep space myspace --project myproject
+devspace sleep space preview-1 preview-2 --project myproject
+devspace sleep space --project myproject --selector env=preview
 #######################################################
 	`
 	}
 
 	c := &cobra.Command{
-		Use:   "space" + util.SpaceNameOnlyUseLine,
+		Use:   "space" + util.SpaceNamesUseLine,
 		Short: "Put a space to sleep",
 		Long:  description,
-		Args:  util.SpaceNameOnlyValidator,
+		Args:  util.SpaceNamesValidator,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.Run(cobraCmd.Context(), args)
 		},
@@ -78,6 +84,7 @@ devspace sleep space myspace --project myproject
 	c.Flags().StringVarP(&cmd.Project, "project", "p", p, "The project to use")
 	c.Flags().Int64Var(&cmd.ForceDuration, "prevent-wakeup", -1, product.Replace("The amount of seconds this space should sleep until it can be woken up again (use 0 for infinite sleeping). During this time the space can only be woken up by `loft wakeup`, manually deleting the annotation on the namespace or through the loft UI"))
 	c.Flags().StringVar(&cmd.Cluster, "cluster", "", "The cluster to use")
+	cmd.Bulk.AddFlags(c.Flags(), "spaces")
 	return c
 }
 
@@ -88,6 +95,17 @@ func (cmd *SpaceCmd) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if cmd.Bulk.Enabled(args) {
+		names, err := cmd.Bulk.Names(cmd.Project, args, bulk.SpaceInstanceNames(ctx, baseClient, cmd.Project))
+		if err != nil {
+			return err
+		}
+
+		return bulk.Run(ctx, "sleep", names, func(ctx context.Context, name string) error {
+			return cmd.sleepSpace(ctx, baseClient, name)
+		}, cmd.Log)
+	}
+
 	spaceName := ""
 	if len(args) > 0 {
 		spaceName = args[0]
@@ -135,7 +153,7 @@ func (cmd *SpaceCmd) sleepSpace(ctx context.Context, baseClient client.Client, s
 	}
 
 	// wait for sleeping
-	cmd.Log.Info("Wait until space is sleeping...")
+	cmd.Log.Infof("Wait until space %s is sleeping...", spaceName)
 	err = wait.PollUntilContextTimeout(ctx, time.Second, config.Timeout(), false, func(ctx context.Context) (done bool, err error) {
 		spaceInstance, err := managementClient.Loft().ManagementV1().SpaceInstances(projectutil.ProjectNamespace(cmd.Project)).Get(ctx, spaceName, metav1.GetOptions{})
 		if err != nil {
@@ -175,7 +193,7 @@ func (cmd *SpaceCmd) legacySleepSpace(ctx context.Context, baseClient client.Cli
 	}
 
 	// wait for sleeping
-	cmd.Log.Info("Wait until space is sleeping...")
+	cmd.Log.Infof("Wait until space %s is sleeping...", spaceName)
 	err = wait.PollUntilContextTimeout(ctx, time.Second, config.Timeout(), false, func(ctx context.Context) (done bool, err error) {
 		configs, err := clusterClient.Agent().ClusterV1().SleepModeConfigs(spaceName).List(ctx, metav1.ListOptions{})
 		if err != nil {
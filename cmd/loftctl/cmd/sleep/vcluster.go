@@ -10,6 +10,7 @@ import (
 	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/bulk"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	"github.com/loft-sh/loftctl/v4/pkg/config"
@@ -29,6 +30,7 @@ type VClusterCmd struct {
 
 	Project       string
 	ForceDuration int64
+	Bulk          bulk.Flags
 
 	Log log.Logger
 }
@@ -46,6 +48,8 @@ Sleep puts a vcluster to sleep
 Example:
 loft sleep vcluster myvcluster
 loft sleep vcluster myvcluster --project myproject
+loft sleep vcluster preview-1 preview-2 --project myproject
+loft sleep vcluster --project myproject --selector env=preview
 ########################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -58,15 +62,17 @@ Sleep puts a vcluster to sleep
 Example:
 devspace sleep vcluster myvcluster
 devspace sleep vcluster myvcluster --project myproject
+devspace sleep vcluster preview-1 preview-2 --project myproject
+devspace sleep vcluster --project myproject --selector env=preview
 ########################################################
 	`
 	}
 
 	c := &cobra.Command{
-		Use:   "vcluster" + util.VClusterNameOnlyUseLine,
+		Use:   "vcluster" + util.VClusterNamesUseLine,
 		Short: "Put a vcluster to sleep",
 		Long:  description,
-		Args:  util.VClusterNameOnlyValidator,
+		Args:  util.VClusterNamesValidator,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.Run(cobraCmd.Context(), args)
 		},
@@ -75,6 +81,7 @@ devspace sleep vcluster myvcluster --project myproject
 	p, _ := defaults.Get(pdefaults.KeyProject, "")
 	c.Flags().StringVarP(&cmd.Project, "project", "p", p, "The project to use")
 	c.Flags().Int64Var(&cmd.ForceDuration, "prevent-wakeup", -1, product.Replace("The amount of seconds this vcluster should sleep until it can be woken up again (use 0 for infinite sleeping). During this time the space can only be woken up by `loft wakeup vcluster`, manually deleting the annotation on the namespace or through the loft UI"))
+	cmd.Bulk.AddFlags(c.Flags(), "vclusters")
 	return c
 }
 
@@ -85,6 +92,17 @@ func (cmd *VClusterCmd) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if cmd.Bulk.Enabled(args) {
+		names, err := cmd.Bulk.Names(cmd.Project, args, bulk.VirtualClusterInstanceNames(ctx, baseClient, cmd.Project))
+		if err != nil {
+			return err
+		}
+
+		return bulk.Run(ctx, "sleep", names, func(ctx context.Context, name string) error {
+			return cmd.sleepVCluster(ctx, baseClient, name)
+		}, cmd.Log)
+	}
+
 	vClusterName := ""
 	if len(args) > 0 {
 		vClusterName = args[0]
@@ -127,7 +145,7 @@ func (cmd *VClusterCmd) sleepVCluster(ctx context.Context, baseClient client.Cli
 	}
 
 	// wait for sleeping
-	cmd.Log.Info("Wait until virtual cluster is sleeping...")
+	cmd.Log.Infof("Wait until vcluster %s is sleeping...", vClusterName)
 	err = wait.PollUntilContextTimeout(ctx, time.Second, config.Timeout(), false, func(ctx context.Context) (done bool, err error) {
 		virtualClusterInstance, err := managementClient.Loft().ManagementV1().VirtualClusterInstances(projectutil.ProjectNamespace(cmd.Project)).Get(ctx, vClusterName, metav1.GetOptions{})
 		if err != nil {
@@ -6,10 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/credentials"
+	"github.com/loft-sh/loftctl/v4/pkg/kubeconfig"
+	"github.com/loft-sh/loftctl/v4/pkg/tokencache"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
 	"github.com/spf13/cobra"
@@ -30,6 +35,7 @@ type TokenCmd struct {
 	VirtualCluster string
 	// Deprecated please use access keys instead
 	DirectClusterEndpoint bool
+	NoCache               bool
 }
 
 // NewTokenCmd creates a new command
@@ -74,12 +80,30 @@ devspace token
 	tokenCmd.Flags().BoolVar(&cmd.DirectClusterEndpoint, "direct-cluster-endpoint", false, "When enabled prints a direct cluster endpoint token")
 	tokenCmd.Flags().StringVar(&cmd.Project, "project", "", "The project containing the virtual cluster")
 	tokenCmd.Flags().StringVar(&cmd.VirtualCluster, "virtual-cluster", "", "The virtual cluster")
+	tokenCmd.Flags().BoolVar(&cmd.NoCache, "no-cache", false, "If enabled, the credential is neither read from nor written to the token cache")
 	return tokenCmd
 }
 
 // Run executes the command
 func (cmd *TokenCmd) Run(ctx context.Context) error {
-	baseClient, err := client.InitClientFromPath(ctx, cmd.Config)
+	baseClient, err := client.NewClientFromPath(cmd.Config)
+	if err != nil {
+		return err
+	}
+
+	// kubectl calls this command for every invocation, so serve credentials from the cache if possible.
+	// The cache stores credentials in plain text, so it's skipped if they are kept in another credential store.
+	cache := tokencache.New(tokenCacheDir())
+	cacheKey := cmd.cacheKey(baseClient.Config())
+	useCache := !cmd.NoCache && credentials.IsPlaintext(baseClient.Config().CredentialStore)
+	if useCache {
+		if credential, ok := cache.Get(cacheKey); ok {
+			cmd.log.Debug("using cached credential")
+			return printCredential(credential)
+		}
+	}
+
+	err = baseClient.RefreshSelf(ctx)
 	if err != nil {
 		return err
 	}
@@ -91,77 +115,111 @@ func (cmd *TokenCmd) Run(ctx context.Context) error {
 		tokenFunc = getCertificate
 	}
 
-	return tokenFunc(cmd, baseClient)
+	credential, err := tokenFunc(cmd, baseClient)
+	if err != nil {
+		return err
+	}
+
+	if useCache {
+		err = cache.Set(cacheKey, credential)
+		if err != nil {
+			cmd.log.Debugf("error caching credential: %v", err)
+		}
+	}
+
+	return printCredential(credential)
 }
 
-func getToken(cmd *TokenCmd, baseClient client.Client) error {
+// cacheKey identifies the credential of the kube context this command was called for. The host and
+// access key are part of the key, so a new login never returns credentials of a previous one.
+func (cmd *TokenCmd) cacheKey(config *client.Config) string {
+	return tokencache.Key(
+		cmd.Config,
+		config.CurrentProfile,
+		config.Host,
+		config.AccessKey,
+		strconv.FormatBool(cmd.DirectClusterEndpoint),
+		cmd.Project,
+		cmd.VirtualCluster,
+	)
+}
+
+// tokenCacheDir is the folder exec credentials are cached in
+func tokenCacheDir() string {
+	return filepath.Join(client.CacheFolder, "token-cache")
+}
+
+func getToken(cmd *TokenCmd, baseClient client.Client) (*v1beta1.ExecCredential, error) {
 	// get config
 	config := baseClient.Config()
 	if config == nil {
-		return ErrNoConfigLoaded
+		return nil, ErrNoConfigLoaded
 	} else if config.Host == "" || config.AccessKey == "" {
-		return fmt.Errorf("%w: please make sure you have run '%s' to create one or '%s [%s]' if one already exists", ErrNotLoggedIn, product.StartCmd(), product.LoginCmd(), product.Url())
+		return nil, fmt.Errorf("%w: please make sure you have run '%s' to create one or '%s [%s]' if one already exists", ErrNotLoggedIn, product.StartCmd(), product.LoginCmd(), product.Url())
 	}
 
 	// by default we print the access key as token
-	token := config.AccessKey
+	credential := newExecCredential(&v1beta1.ExecCredentialStatus{
+		Token: config.AccessKey,
+	})
 
 	// check if we should print a cluster gateway token instead
 	if cmd.DirectClusterEndpoint {
 		var err error
-		token, err = baseClient.DirectClusterEndpointToken(false)
+		credential.Status.Token, err = baseClient.DirectClusterEndpointToken(false)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		// the client refreshes the token once it's older than client.RefreshToken
+		if config.DirectClusterEndpointTokenRequested != nil {
+			expiration := metav1.NewTime(config.DirectClusterEndpointTokenRequested.Add(client.RefreshToken))
+			credential.Status.ExpirationTimestamp = &expiration
 		}
 	}
 
-	return printToken(token)
+	return credential, nil
 }
 
-func printToken(token string) error {
-	// Print token to stdout
-	response := &v1beta1.ExecCredential{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "ExecCredential",
-			APIVersion: v1beta1.SchemeGroupVersion.String(),
-		},
-		Status: &v1beta1.ExecCredentialStatus{
-			Token: token,
-		},
-	}
-
-	bytes, err := json.Marshal(response)
+func getCertificate(cmd *TokenCmd, baseClient client.Client) (*v1beta1.ExecCredential, error) {
+	certificateData, keyData, err := baseClient.VirtualClusterAccessPointCertificate(cmd.Project, cmd.VirtualCluster, false)
 	if err != nil {
-		return fmt.Errorf("json marshal: %w", err)
+		return nil, err
 	}
 
-	_, err = os.Stdout.Write(bytes)
-	return err
-}
+	credential := newExecCredential(&v1beta1.ExecCredentialStatus{
+		ClientCertificateData: certificateData,
+		ClientKeyData:         keyData,
+	})
+
+	// the client refreshes the certificate once it's older than client.RefreshToken or expired
+	contextName := kubeconfig.VirtualClusterInstanceContextName(cmd.Project, cmd.VirtualCluster)
+	if entry, ok := baseClient.Config().VirtualClusterAccessPointCertificates[contextName]; ok {
+		expiration := entry.LastRequested.Add(client.RefreshToken)
+		if entry.ExpirationTime.Before(expiration) {
+			expiration = entry.ExpirationTime
+		}
 
-func getCertificate(cmd *TokenCmd, baseClient client.Client) error {
-	certificateData, keyData, err := baseClient.VirtualClusterAccessPointCertificate(cmd.Project, cmd.VirtualCluster, false)
-	if err != nil {
-		return err
+		expirationTimestamp := metav1.NewTime(expiration)
+		credential.Status.ExpirationTimestamp = &expirationTimestamp
 	}
 
-	return printCertificate(certificateData, keyData)
+	return credential, nil
 }
 
-func printCertificate(certificateData, keyData string) error {
-	// Print certificate-based exec credential to stdout
-	response := &v1beta1.ExecCredential{
+func newExecCredential(status *v1beta1.ExecCredentialStatus) *v1beta1.ExecCredential {
+	return &v1beta1.ExecCredential{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ExecCredential",
 			APIVersion: v1beta1.SchemeGroupVersion.String(),
 		},
-		Status: &v1beta1.ExecCredentialStatus{
-			ClientCertificateData: certificateData,
-			ClientKeyData:         keyData,
-		},
+		Status: status,
 	}
+}
 
-	bytes, err := json.Marshal(response)
+func printCredential(credential *v1beta1.ExecCredential) error {
+	// Print exec credential to stdout
+	bytes, err := json.Marshal(credential)
 	if err != nil {
 		return fmt.Errorf("json marshal: %w", err)
 	}
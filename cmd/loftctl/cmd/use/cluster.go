@@ -174,6 +174,7 @@ func CreateClusterContextOptions(baseClient client.Client, config string, cluste
 	contextOptions := kubeconfig.ContextOptions{
 		Name:             kubeconfig.SpaceContextName(cluster.Name, spaceName),
 		ConfigPath:       config,
+		Profile:          baseClient.Config().CurrentProfile,
 		CurrentNamespace: spaceName,
 		SetActive:        setActive,
 	}
@@ -185,14 +186,19 @@ func CreateClusterContextOptions(baseClient client.Client, config string, cluste
 		}
 	} else {
 		contextOptions.Server = baseClient.Config().Host + "/kubernetes/cluster/" + cluster.Name
-		contextOptions.InsecureSkipTLSVerify = baseClient.Config().Insecure
+		err := applyLoftTLSOptions(&contextOptions, baseClient)
+		if err != nil {
+			return kubeconfig.ContextOptions{}, err
+		}
 	}
 
 	data, err := retrieveCaData(cluster)
 	if err != nil {
 		return kubeconfig.ContextOptions{}, err
 	}
-	contextOptions.CaData = data
+	if data != nil {
+		contextOptions.CaData = data
+	}
 	return contextOptions, nil
 }
 
@@ -211,6 +217,19 @@ func ApplyDirectClusterEndpointOptions(options kubeconfig.ContextOptions, cluste
 	return options
 }
 
+// applyLoftTLSOptions verifies the loft host of the context with the tls options of the cli config
+func applyLoftTLSOptions(options *kubeconfig.ContextOptions, baseClient client.Client) error {
+	tlsOptions := baseClient.Config().TLSOptions()
+	caData, err := tlsOptions.CAData()
+	if err != nil {
+		return err
+	}
+
+	options.CaData = caData
+	options.InsecureSkipTLSVerify = tlsOptions.Insecure && len(caData) == 0
+	return nil
+}
+
 func retrieveCaData(cluster *managementv1.Cluster) ([]byte, error) {
 	if cluster == nil || cluster.Annotations == nil || cluster.Annotations[LoftDirectClusterEndpointCaData] == "" {
 		return nil, nil
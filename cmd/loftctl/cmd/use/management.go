@@ -102,11 +102,15 @@ func CreateManagementContextOptions(baseClient client.Client, config string, set
 	contextOptions := kubeconfig.ContextOptions{
 		Name:       kubeconfig.ManagementContextName(),
 		ConfigPath: config,
+		Profile:    baseClient.Config().CurrentProfile,
 		SetActive:  setActive,
 	}
 
 	contextOptions.Server = baseClient.Config().Host + "/kubernetes/management"
-	contextOptions.InsecureSkipTLSVerify = baseClient.Config().Insecure
+	err := applyLoftTLSOptions(&contextOptions, baseClient)
+	if err != nil {
+		return kubeconfig.ContextOptions{}, err
+	}
 
 	return contextOptions, nil
 }
@@ -208,6 +208,7 @@ func CreateSpaceInstanceOptions(ctx context.Context, baseClient client.Client, c
 	contextOptions := kubeconfig.ContextOptions{
 		Name:             kubeconfig.SpaceInstanceContextName(projectName, spaceInstance.Name),
 		ConfigPath:       config,
+		Profile:          baseClient.Config().CurrentProfile,
 		CurrentNamespace: spaceInstance.Spec.ClusterRef.Namespace,
 		SetActive:        setActive,
 	}
@@ -219,13 +220,18 @@ func CreateSpaceInstanceOptions(ctx context.Context, baseClient client.Client, c
 		}
 	} else {
 		contextOptions.Server = baseClient.Config().Host + "/kubernetes/project/" + projectName + "/space/" + spaceInstance.Name
-		contextOptions.InsecureSkipTLSVerify = baseClient.Config().Insecure
+		err := applyLoftTLSOptions(&contextOptions, baseClient)
+		if err != nil {
+			return kubeconfig.ContextOptions{}, err
+		}
 	}
 
 	data, err := retrieveCaData(cluster)
 	if err != nil {
 		return kubeconfig.ContextOptions{}, err
 	}
-	contextOptions.CaData = data
+	if data != nil {
+		contextOptions.CaData = data
+	}
 	return contextOptions, nil
 }
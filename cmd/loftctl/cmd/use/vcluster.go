@@ -182,6 +182,7 @@ func CreateVirtualClusterInstanceOptions(ctx context.Context, baseClient client.
 	contextOptions := kubeconfig.ContextOptions{
 		Name:       kubeconfig.VirtualClusterInstanceContextName(projectName, virtualClusterInstance.Name),
 		ConfigPath: config,
+		Profile:    baseClient.Config().CurrentProfile,
 		SetActive:  setActive,
 	}
 	if virtualClusterInstance.Status.VirtualCluster != nil && virtualClusterInstance.Status.VirtualCluster.AccessPoint.Ingress.Enabled {
@@ -206,14 +207,19 @@ func CreateVirtualClusterInstanceOptions(ctx context.Context, baseClient client.
 			}
 		} else {
 			contextOptions.Server = baseClient.Config().Host + "/kubernetes/project/" + projectName + "/virtualcluster/" + virtualClusterInstance.Name
-			contextOptions.InsecureSkipTLSVerify = baseClient.Config().Insecure
+			err := applyLoftTLSOptions(&contextOptions, baseClient)
+			if err != nil {
+				return kubeconfig.ContextOptions{}, err
+			}
 		}
 
 		data, err := retrieveCaData(cluster)
 		if err != nil {
 			return kubeconfig.ContextOptions{}, err
 		}
-		contextOptions.CaData = data
+		if data != nil {
+			contextOptions.CaData = data
+		}
 	}
 	return contextOptions, nil
 }
@@ -272,6 +278,7 @@ func CreateVClusterContextOptions(baseClient client.Client, config string, clust
 	contextOptions := kubeconfig.ContextOptions{
 		Name:       kubeconfig.VirtualClusterContextName(cluster.Name, spaceName, virtualClusterName),
 		ConfigPath: config,
+		Profile:    baseClient.Config().CurrentProfile,
 		SetActive:  setActive,
 	}
 	if !disableClusterGateway && cluster.Annotations != nil && cluster.Annotations[LoftDirectClusterEndpoint] != "" {
@@ -282,14 +289,19 @@ func CreateVClusterContextOptions(baseClient client.Client, config string, clust
 		}
 	} else {
 		contextOptions.Server = baseClient.Config().Host + "/kubernetes/virtualcluster/" + cluster.Name + "/" + spaceName + "/" + virtualClusterName
-		contextOptions.InsecureSkipTLSVerify = baseClient.Config().Insecure
+		err := applyLoftTLSOptions(&contextOptions, baseClient)
+		if err != nil {
+			return kubeconfig.ContextOptions{}, err
+		}
 	}
 
 	data, err := retrieveCaData(cluster)
 	if err != nil {
 		return kubeconfig.ContextOptions{}, err
 	}
-	contextOptions.CaData = data
+	if data != nil {
+		contextOptions.CaData = data
+	}
 	return contextOptions, nil
 }
 
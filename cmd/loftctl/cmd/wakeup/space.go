@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/bulk"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	pdefaults "github.com/loft-sh/loftctl/v4/pkg/defaults"
@@ -30,6 +31,7 @@ type SpaceCmd struct {
 
 	Project string
 	Cluster string
+	Bulk    bulk.Flags
 	Log     log.Logger
 }
 
@@ -46,6 +48,8 @@ wakeup resumes a sleeping space
 Example:
 loft wakeup space myspace
 loft wakeup space myspace --project myproject
+loft wakeup space preview-1 preview-2 --project myproject
+loft wakeup space --project myproject --selector env=preview
 #######################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -58,15 +62,17 @@ wakeup resumes a sleeping space
 Example:
 devspace wakeup space myspace
 devspace wakeup space myspace --project myproject
+devspace wakeup space preview-1 preview-2 --project myproject
+devspace wakeup space --project myproject --selector env=preview
 #######################################################
 	`
 	}
 
 	c := &cobra.Command{
-		Use:   "space" + util.SpaceNameOnlyUseLine,
+		Use:   "space" + util.SpaceNamesUseLine,
 		Short: "Wakes up a space",
 		Long:  description,
-		Args:  util.SpaceNameOnlyValidator,
+		Args:  util.SpaceNamesValidator,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.Run(cobraCmd.Context(), args)
 		},
@@ -75,6 +81,7 @@ devspace wakeup space myspace --project myproject
 	p, _ := defaults.Get(pdefaults.KeyProject, "")
 	c.Flags().StringVarP(&cmd.Project, "project", "p", p, "The project to use")
 	c.Flags().StringVar(&cmd.Cluster, "cluster", "", "The cluster to use")
+	cmd.Bulk.AddFlags(c.Flags(), "spaces")
 	return c
 }
 
@@ -85,6 +92,17 @@ func (cmd *SpaceCmd) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if cmd.Bulk.Enabled(args) {
+		names, err := cmd.Bulk.Names(cmd.Project, args, bulk.SpaceInstanceNames(ctx, baseClient, cmd.Project))
+		if err != nil {
+			return err
+		}
+
+		return bulk.Run(ctx, "wake up", names, func(ctx context.Context, name string) error {
+			return cmd.spaceWakeUp(ctx, baseClient, name)
+		}, cmd.Log)
+	}
+
 	spaceName := ""
 	if len(args) > 0 {
 		spaceName = args[0]
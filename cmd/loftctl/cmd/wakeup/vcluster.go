@@ -6,6 +6,7 @@ import (
 
 	"github.com/loft-sh/api/v4/pkg/product"
 	"github.com/loft-sh/loftctl/v4/cmd/loftctl/flags"
+	"github.com/loft-sh/loftctl/v4/pkg/bulk"
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
 	pdefaults "github.com/loft-sh/loftctl/v4/pkg/defaults"
@@ -22,6 +23,7 @@ type VClusterCmd struct {
 	*flags.GlobalFlags
 
 	Project string
+	Bulk    bulk.Flags
 
 	Log log.Logger
 }
@@ -39,6 +41,8 @@ Wakes up a vcluster
 Example:
 loft wakeup vcluster myvcluster
 loft wakeup vcluster myvcluster --project myproject
+loft wakeup vcluster preview-1 preview-2 --project myproject
+loft wakeup vcluster --project myproject --selector env=preview
 ########################################################
 	`)
 	if upgrade.IsPlugin == "true" {
@@ -51,15 +55,17 @@ Wakes up a vcluster
 Example:
 devspace wakeup vcluster myvcluster
 devspace wakeup vcluster myvcluster --project myproject
+devspace wakeup vcluster preview-1 preview-2 --project myproject
+devspace wakeup vcluster --project myproject --selector env=preview
 ########################################################
 	`
 	}
 
 	c := &cobra.Command{
-		Use:   "vcluster" + util.VClusterNameOnlyUseLine,
+		Use:   "vcluster" + util.VClusterNamesUseLine,
 		Short: "Wake up a vcluster",
 		Long:  description,
-		Args:  util.VClusterNameOnlyValidator,
+		Args:  util.VClusterNamesValidator,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.Run(cobraCmd.Context(), args)
 		},
@@ -67,6 +73,7 @@ devspace wakeup vcluster myvcluster --project myproject
 
 	p, _ := defaults.Get(pdefaults.KeyProject, "")
 	c.Flags().StringVarP(&cmd.Project, "project", "p", p, "The project to use")
+	cmd.Bulk.AddFlags(c.Flags(), "vclusters")
 	return c
 }
 
@@ -77,6 +84,17 @@ func (cmd *VClusterCmd) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if cmd.Bulk.Enabled(args) {
+		names, err := cmd.Bulk.Names(cmd.Project, args, bulk.VirtualClusterInstanceNames(ctx, baseClient, cmd.Project))
+		if err != nil {
+			return err
+		}
+
+		return bulk.Run(ctx, "wake up", names, func(ctx context.Context, name string) error {
+			return cmd.wakeUpVCluster(ctx, baseClient, name)
+		}, cmd.Log)
+	}
+
 	vClusterName := ""
 	if len(args) > 0 {
 		vClusterName = args[0]
@@ -9,9 +9,14 @@ import (
 // GlobalFlags is the flags that contains the global flags
 type GlobalFlags struct {
 	Config    string
+	Profile   string
 	LogOutput string
 	Silent    bool
 	Debug     bool
+
+	Trace       bool
+	TraceBodies bool
+	TraceHAR    string
 }
 
 // SetGlobalFlags applies the global flags
@@ -20,7 +25,11 @@ func SetGlobalFlags(flags *flag.FlagSet) *GlobalFlags {
 
 	flags.StringVar(&globalFlags.LogOutput, "log-output", "plain", "The log format to use. Can be either plain, raw or json")
 	flags.StringVar(&globalFlags.Config, "config", client.DefaultCacheConfig, product.Replace("The loft config to use (will be created if it does not exist)"))
+	flags.StringVar(&globalFlags.Profile, "profile", "", "The login profile of the config to use. Can also be set via the LOFT_PROFILE environment variable")
 	flags.BoolVar(&globalFlags.Debug, "debug", false, "Prints additional log messages to the output. Useful for debugging.")
+	flags.BoolVar(&globalFlags.Trace, "trace", false, product.Replace("Prints every request to the loft management api with its status and latency to stderr. Can also be set via the LOFT_TRACE environment variable"))
+	flags.BoolVar(&globalFlags.TraceBodies, "trace-bodies", false, "If enabled, traced requests also print their request and response bodies. Secrets are redacted on a best effort basis")
+	flags.StringVar(&globalFlags.TraceHAR, "trace-har", "", "Writes all traced requests as HAR file to the given path, e.g. to attach it to a support ticket. Can also be set via the LOFT_TRACE_HAR environment variable")
 	flags.BoolVar(&globalFlags.Silent, "silent", false, product.Replace("Run in silent mode and prevents any loft log output except panics & fatals"))
 
 	return globalFlags
@@ -0,0 +1,270 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ManifestFileName is the name of the manifest within an archive backup
+const ManifestFileName = "manifest.json"
+
+// Manifest describes the contents of an archive backup
+type Manifest struct {
+	// CLIVersion is the version of the cli that created the backup
+	CLIVersion string `json:"cliVersion,omitempty"`
+
+	// LoftVersion is the version of the management plane the backup was created from
+	LoftVersion string `json:"loftVersion,omitempty"`
+
+	// Created is the time the backup was created at
+	Created time.Time `json:"created"`
+
+	// Skipped holds the resources that were skipped during the backup
+	Skipped []string `json:"skipped,omitempty"`
+
+	// Only holds the resources the backup was limited to, all if empty
+	Only []string `json:"only,omitempty"`
+
+	// Projects holds the projects the backup was limited to, all if empty
+	Projects []string `json:"projects,omitempty"`
+
+	// Selector is the label selector the backed up objects had to match
+	Selector string `json:"selector,omitempty"`
+
+	// Files holds all object files within the archive
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile is a single object file within an archive backup
+type ManifestFile struct {
+	Path      string `json:"path"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	SHA256    string `json:"sha256"`
+}
+
+// IsArchive checks if the given data is a gzip compressed archive backup
+func IsArchive(data []byte) bool {
+	return len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// ToArchive writes the given objects into a tar.gz archive with one file per object,
+// grouped by kind and namespace, and a manifest with the checksum of every file
+func ToArchive(objects []runtime.Object, manifest Manifest) ([]byte, error) {
+	files := map[string][]byte{}
+	manifest.Files = []ManifestFile{}
+	for _, o := range objects {
+		accessor, err := meta.Accessor(o)
+		if err != nil {
+			return nil, err
+		} else if accessor.GetName() == "" {
+			// empty objects are written for secrets that could not be found during backup
+			continue
+		}
+
+		file := ManifestFile{
+			Kind:      o.GetObjectKind().GroupVersionKind().Kind,
+			Namespace: accessor.GetNamespace(),
+			Name:      accessor.GetName(),
+		}
+		file.Path = archivePath(file.Kind, file.Namespace, file.Name)
+		out, err := yaml.Marshal(o)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal object")
+		}
+
+		// the same secret is often referenced several times, e.g. as password and codes of a user
+		if existing, ok := files[file.Path]; ok {
+			if bytes.Equal(existing, out) {
+				continue
+			}
+
+			return nil, fmt.Errorf("conflicting objects %s in backup", file.Path)
+		}
+
+		file.SHA256 = checksum(out)
+		files[file.Path] = out
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal manifest")
+	}
+
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err = writeArchiveFile(tarWriter, ManifestFileName, manifestBytes, manifest.Created)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range manifest.Files {
+		err = writeArchiveFile(tarWriter, file.Path, files[file.Path], manifest.Created)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return nil, err
+	}
+	err = gzipWriter.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// VerifyArchive checks every file of the archive against the checksums in its manifest
+func VerifyArchive(data []byte) (*Manifest, []error) {
+	manifest, files, err := readArchive(data)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	return manifest, verifyFiles(manifest, files)
+}
+
+func verifyFiles(manifest *Manifest, files map[string][]byte) []error {
+	verifyErrors := []error{}
+	listed := map[string]bool{}
+	for _, file := range manifest.Files {
+		listed[file.Path] = true
+
+		content, ok := files[file.Path]
+		if !ok {
+			verifyErrors = append(verifyErrors, fmt.Errorf("file %s is missing", file.Path))
+		} else if sum := checksum(content); sum != file.SHA256 {
+			verifyErrors = append(verifyErrors, fmt.Errorf("file %s has checksum %s, expected %s", file.Path, sum, file.SHA256))
+		}
+	}
+	for filePath := range files {
+		if !listed[filePath] {
+			verifyErrors = append(verifyErrors, fmt.Errorf("file %s is not part of the manifest", filePath))
+		}
+	}
+
+	return verifyErrors
+}
+
+// FromArchive verifies the given archive and decodes its objects in manifest order
+func FromArchive(scheme *runtime.Scheme, data []byte) ([]runtime.Object, *Manifest, error) {
+	manifest, files, err := readArchive(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	verifyErrors := verifyFiles(manifest, files)
+	if len(verifyErrors) > 0 {
+		return nil, nil, errors.Wrap(verifyErrors[0], "verify archive")
+	}
+
+	objects := []runtime.Object{}
+	for _, file := range manifest.Files {
+		objs, err := FromYAML(scheme, files[file.Path])
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "decode %s", file.Path)
+		}
+
+		objects = append(objects, objs...)
+	}
+
+	return objects, manifest, nil
+}
+
+func readArchive(data []byte) (*Manifest, map[string][]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "open archive")
+	}
+	defer gzipReader.Close()
+
+	var manifest *Manifest
+	files := map[string][]byte{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, nil, errors.Wrap(err, "read archive")
+		} else if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "read %s", header.Name)
+		}
+
+		if header.Name == ManifestFileName {
+			manifest = &Manifest{}
+			err = json.Unmarshal(content, manifest)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "parse manifest")
+			}
+
+			continue
+		}
+
+		files[header.Name] = content
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("archive has no %s", ManifestFileName)
+	}
+
+	return manifest, files, nil
+}
+
+func writeArchiveFile(tarWriter *tar.Writer, name string, content []byte, modTime time.Time) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(content)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "write header %s", name)
+	}
+
+	_, err = tarWriter.Write(content)
+	if err != nil {
+		return errors.Wrapf(err, "write %s", name)
+	}
+
+	return nil
+}
+
+func archivePath(kind, namespace, name string) string {
+	if namespace == "" {
+		return path.Join(strings.ToLower(kind), name+".yaml")
+	}
+
+	return path.Join(strings.ToLower(kind), namespace, name+".yaml")
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,83 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestArchive(t *testing.T) {
+	objects := []runtime.Object{
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "loft"},
+			Data:       map[string][]byte{"password": []byte("a")},
+		},
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		},
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "loft"},
+		},
+	}
+
+	out, err := ToArchive(objects, Manifest{
+		CLIVersion: "v4.0.0",
+		Created:    time.Unix(0, 0).UTC(),
+		Skipped:    []string{"users"},
+		Projects:   []string{"default"},
+		Selector:   "team=frontend",
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, IsArchive(out))
+
+	manifest, verifyErrors := VerifyArchive(out)
+	assert.Equal(t, len(verifyErrors), 0)
+	assert.Equal(t, manifest.CLIVersion, "v4.0.0")
+	assert.DeepEqual(t, manifest.Skipped, []string{"users"})
+	assert.DeepEqual(t, manifest.Projects, []string{"default"})
+	assert.Equal(t, manifest.Selector, "team=frontend")
+	assert.Equal(t, len(manifest.Files), 2)
+	assert.Equal(t, manifest.Files[0].Path, "secret/loft/a.yaml")
+	assert.Equal(t, manifest.Files[1].Path, "namespace/loft.yaml")
+
+	restored, _, err := FromArchive(clientgoscheme.Scheme, out)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, restored, []runtime.Object{objects[0], objects[2]})
+}
+
+func TestVerifyArchiveChecksum(t *testing.T) {
+	manifest := &Manifest{
+		Files: []ManifestFile{{Path: "secret/loft/a.yaml", SHA256: checksum([]byte("a"))}},
+	}
+
+	verifyErrors := verifyFiles(manifest, map[string][]byte{"secret/loft/a.yaml": []byte("b"), "secret/loft/b.yaml": []byte("b")})
+	assert.Equal(t, len(verifyErrors), 2)
+	assert.ErrorContains(t, verifyErrors[0], "has checksum")
+	assert.ErrorContains(t, verifyErrors[1], "not part of the manifest")
+}
+
+func TestArchiveDuplicates(t *testing.T) {
+	secret := func(password string) runtime.Object {
+		return &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "loft-user-secret-admin", Namespace: "loft"},
+			Data:       map[string][]byte{"password": []byte(password)},
+		}
+	}
+
+	out, err := ToArchive([]runtime.Object{secret("a"), secret("a")}, Manifest{Created: time.Unix(0, 0).UTC()})
+	assert.NilError(t, err)
+	manifest, verifyErrors := VerifyArchive(out)
+	assert.Equal(t, len(verifyErrors), 0)
+	assert.Equal(t, len(manifest.Files), 1)
+
+	_, err = ToArchive([]runtime.Object{secret("a"), secret("b")}, Manifest{Created: time.Unix(0, 0).UTC()})
+	assert.ErrorContains(t, err, "conflicting objects secret/loft/loft-user-secret-admin.yaml")
+}
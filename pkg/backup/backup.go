@@ -19,210 +19,123 @@ import (
 
 type LogFn func(msg string)
 
-func All(ctx context.Context, client clientpkg.Client, skip []string, infoFn LogFn) ([]runtime.Object, []error) {
-	objects := []runtime.Object{}
-	backupErrors := []error{}
-	if !contains(skip, "clusterroletemplates") {
-		infoFn("Backing up clusterroletemplates...")
-		objs, err := clusterRoles(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup clusterrole templates"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "clusteraccesses") {
-		infoFn("Backing up clusteraccesses...")
-		users, err := clusterAccess(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup cluster accesses"))
-		} else {
-			objects = append(objects, users...)
-		}
-	}
-	if !contains(skip, "spaceconstraints") {
-		infoFn("Backing up spaceconstraints...")
-		objs, err := spaceConstraints(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup space constrains"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "users") {
-		infoFn("Backing up users...")
-		objs, err := users(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup users"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "teams") {
-		infoFn("Backing up teams...")
-		objs, err := teams(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup teams"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "sharedsecrets") {
-		infoFn("Backing up shared secrets...")
-		objs, err := sharedSecrets(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup shared secrets"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "accesskeys") {
-		infoFn("Backing up access keys...")
-		objs, err := accessKeys(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup access keys"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "apps") {
-		infoFn("Backing up apps...")
-		objs, err := apps(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup apps"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "spacetemplates") {
-		infoFn("Backing up space templates...")
-		objs, err := spaceTemplates(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup space templates"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "virtualclustertemplates") {
-		infoFn("Backing up virtual cluster templates...")
-		objs, err := virtualClusterTemplate(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup virtual cluster templates"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "devpodworkspacetemplates") {
-		infoFn("Backing up devpod workspace templates...")
-		objs, err := devPodWorkspaceTemplate(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup devpod workspace templates"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "clusters") {
-		infoFn("Backing up clusters...")
-		objs, err := clusters(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup clusters"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	if !contains(skip, "runners") {
-		infoFn("Backing up runners...")
-		objs, err := runners(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup runners"))
-		} else {
-			objects = append(objects, objs...)
-		}
-	}
-	projects := []string{}
-	if !contains(skip, "projects") {
-		infoFn("Backing up projects...")
-		objs, projectNames, err := allProjects(ctx, client)
-		if err != nil {
-			backupErrors = append(backupErrors, errors.Wrap(err, "backup projects"))
-		} else {
-			objects = append(objects, objs...)
-		}
-
-		projects = projectNames
-	}
-	if len(projects) > 0 {
-		if !contains(skip, "virtualclusterinstances") {
-			infoFn("Backing up virtual cluster instances...")
-			objs, err := virtualClusterInstances(ctx, client, projects)
-			if err != nil {
-				backupErrors = append(backupErrors, errors.Wrap(err, "backup virtual cluster instances"))
-			} else {
-				objects = append(objects, objs...)
+// All backs up all management plane objects from the host cluster
+func All(ctx context.Context, client clientpkg.Client, options Options) ([]runtime.Object, []error) {
+	opts := options.listOptions()
+	return runPlan(ctx, backupPlan{
+		collectors: []collector{
+			{name: "clusterroletemplates", resource: "clusterrole templates", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return clusterRoles(ctx, client, opts...)
+			}},
+			{name: "clusteraccesses", resource: "cluster accesses", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return clusterAccess(ctx, client, opts...)
+			}},
+			{name: "spaceconstraints", resource: "space constraints", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return spaceConstraints(ctx, client, opts...)
+			}},
+			{name: "users", resource: "users", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return users(ctx, client, opts...)
+			}},
+			{name: "teams", resource: "teams", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return teams(ctx, client, opts...)
+			}},
+			{name: "sharedsecrets", resource: "shared secrets", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return sharedSecrets(ctx, client, opts...)
+			}},
+			{name: "accesskeys", resource: "access keys", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return accessKeys(ctx, client, opts...)
+			}},
+			{name: "apps", resource: "apps", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return apps(ctx, client, opts...)
+			}},
+			{name: "spacetemplates", resource: "space templates", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return spaceTemplates(ctx, client, opts...)
+			}},
+			{name: "virtualclustertemplates", resource: "virtual cluster templates", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return virtualClusterTemplate(ctx, client, opts...)
+			}},
+			{name: "devpodworkspacetemplates", resource: "devpod workspace templates", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return devPodWorkspaceTemplate(ctx, client, opts...)
+			}},
+			{name: "clusters", resource: "clusters", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return clusters(ctx, client, opts...)
+			}},
+			{name: "runners", resource: "runners", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return runners(ctx, client, opts...)
+			}},
+			{name: "projects", resource: "projects", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return allProjects(ctx, client, opts...)
+			}},
+		},
+		projectCollectors: func(projects []string) []collector {
+			return []collector{
+				{name: "virtualclusterinstances", resource: "virtual cluster instances", collect: func(ctx context.Context) ([]runtime.Object, error) {
+					return virtualClusterInstances(ctx, client, projects, opts...)
+				}},
+				{name: "devpodworkspaceinstances", resource: "devpod workspace instances", collect: func(ctx context.Context) ([]runtime.Object, error) {
+					return devPodWorkspaceInstances(ctx, client, projects, opts...)
+				}},
+				{name: "spaceinstances", resource: "space instances", collect: func(ctx context.Context) ([]runtime.Object, error) {
+					return spaceInstances(ctx, client, projects, opts...)
+				}},
+				{name: "projectsecrets", resource: "project secrets", collect: func(ctx context.Context) ([]runtime.Object, error) {
+					return projectSecrets(ctx, client, projects, opts...)
+				}},
 			}
-		}
-		if !contains(skip, "devpodworkspaceinstances") {
-			infoFn("Backing up devpod workspace instances...")
-			objs, err := devPodWorkspaceInstances(ctx, client, projects)
-			if err != nil {
-				backupErrors = append(backupErrors, errors.Wrap(err, "backup devpod workspace instances"))
-			} else {
-				objects = append(objects, objs...)
-			}
-		}
-		if !contains(skip, "spaceinstances") {
-			infoFn("Backing up space instances...")
-			objs, err := spaceInstances(ctx, client, projects)
+		},
+		listProjects: func(ctx context.Context) ([]string, error) {
+			objs, err := allProjects(ctx, client)
 			if err != nil {
-				backupErrors = append(backupErrors, errors.Wrap(err, "backup space instances"))
-			} else {
-				objects = append(objects, objs...)
-			}
-		}
-		if !contains(skip, "projectsecrets") {
-			infoFn("Backing up project secrets...")
-			objs, err := projectSecrets(ctx, client, projects)
-			if err != nil {
-				backupErrors = append(backupErrors, errors.Wrap(err, "backup project secrets"))
-			} else {
-				objects = append(objects, objs...)
+				return nil, err
 			}
+
+			return projectNames(objs), nil
+		},
+		getTemplates: func(ctx context.Context, kind string, names []string) ([]runtime.Object, error) {
+			return templates(ctx, client, kind, names)
+		},
+	}, options)
+}
+
+// projectNames returns the names of all projects within the given objects
+func projectNames(objects []runtime.Object) []string {
+	names := []string{}
+	for _, obj := range objects {
+		if project, ok := obj.(*storagev1.Project); ok {
+			names = append(names, project.Name)
 		}
 	}
 
-	return objects, backupErrors
+	return names
 }
 
-func allProjects(ctx context.Context, client clientpkg.Client) ([]runtime.Object, []string, error) {
+func allProjects(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	projectList := &storagev1.ProjectList{}
-	err := client.List(ctx, projectList)
+	err := client.List(ctx, projectList, opts...)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	retList := []runtime.Object{}
-
-	projectNames := []string{}
 	for _, project := range projectList.Items {
 		u := project
 
 		err := resetMetadata(client.Scheme(), &u)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		retList = append(retList, &u)
-		projectNames = append(projectNames, u.Name)
 	}
 
-	return retList, projectNames, nil
+	return retList, nil
 }
 
-func virtualClusterInstances(ctx context.Context, client clientpkg.Client, projects []string) ([]runtime.Object, error) {
+func virtualClusterInstances(ctx context.Context, client clientpkg.Client, projects []string, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	retList := []runtime.Object{}
 	for _, projectName := range projects {
 		virtualClusterInstanceList := &storagev1.VirtualClusterInstanceList{}
-		err := client.List(ctx, virtualClusterInstanceList, clientpkg.InNamespace(projectutil.ProjectNamespace(projectName)))
+		err := client.List(ctx, virtualClusterInstanceList, append([]clientpkg.ListOption{clientpkg.InNamespace(projectutil.ProjectNamespace(projectName))}, opts...)...)
 		if err != nil {
 			return nil, err
 		}
@@ -242,11 +155,11 @@ func virtualClusterInstances(ctx context.Context, client clientpkg.Client, proje
 	return retList, nil
 }
 
-func devPodWorkspaceInstances(ctx context.Context, client clientpkg.Client, projects []string) ([]runtime.Object, error) {
+func devPodWorkspaceInstances(ctx context.Context, client clientpkg.Client, projects []string, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	retList := []runtime.Object{}
 	for _, projectName := range projects {
 		devPodWorkspaceInstanceList := &storagev1.DevPodWorkspaceInstanceList{}
-		err := client.List(ctx, devPodWorkspaceInstanceList, clientpkg.InNamespace(projectutil.ProjectNamespace(projectName)))
+		err := client.List(ctx, devPodWorkspaceInstanceList, append([]clientpkg.ListOption{clientpkg.InNamespace(projectutil.ProjectNamespace(projectName))}, opts...)...)
 		if err != nil {
 			return nil, err
 		}
@@ -266,11 +179,11 @@ func devPodWorkspaceInstances(ctx context.Context, client clientpkg.Client, proj
 	return retList, nil
 }
 
-func spaceInstances(ctx context.Context, client clientpkg.Client, projects []string) ([]runtime.Object, error) {
+func spaceInstances(ctx context.Context, client clientpkg.Client, projects []string, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	retList := []runtime.Object{}
 	for _, projectName := range projects {
 		spaceInstanceList := &storagev1.SpaceInstanceList{}
-		err := client.List(ctx, spaceInstanceList, clientpkg.InNamespace(projectutil.ProjectNamespace(projectName)))
+		err := client.List(ctx, spaceInstanceList, append([]clientpkg.ListOption{clientpkg.InNamespace(projectutil.ProjectNamespace(projectName))}, opts...)...)
 		if err != nil {
 			return nil, err
 		}
@@ -290,11 +203,11 @@ func spaceInstances(ctx context.Context, client clientpkg.Client, projects []str
 	return retList, nil
 }
 
-func projectSecrets(ctx context.Context, client clientpkg.Client, projects []string) ([]runtime.Object, error) {
+func projectSecrets(ctx context.Context, client clientpkg.Client, projects []string, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	retList := []runtime.Object{}
 	for _, projectName := range projects {
 		secretList := &corev1.SecretList{}
-		err := client.List(ctx, secretList, clientpkg.InNamespace(projectutil.ProjectNamespace(projectName)))
+		err := client.List(ctx, secretList, append([]clientpkg.ListOption{clientpkg.InNamespace(projectutil.ProjectNamespace(projectName))}, opts...)...)
 		if err != nil {
 			return nil, err
 		}
@@ -318,9 +231,9 @@ func projectSecrets(ctx context.Context, client clientpkg.Client, projects []str
 	return retList, nil
 }
 
-func clusters(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func clusters(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	clusterList := &storagev1.ClusterList{}
-	err := client.List(ctx, clusterList)
+	err := client.List(ctx, clusterList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -350,9 +263,9 @@ func clusters(ctx context.Context, client clientpkg.Client) ([]runtime.Object, e
 	return retList, nil
 }
 
-func runners(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func runners(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	runnerList := &storagev1.RunnerList{}
-	err := client.List(ctx, runnerList)
+	err := client.List(ctx, runnerList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -372,9 +285,9 @@ func runners(ctx context.Context, client clientpkg.Client) ([]runtime.Object, er
 	return retList, nil
 }
 
-func clusterRoles(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func clusterRoles(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	objs := &storagev1.ClusterRoleTemplateList{}
-	err := client.List(ctx, objs)
+	err := client.List(ctx, objs, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -394,9 +307,9 @@ func clusterRoles(ctx context.Context, client clientpkg.Client) ([]runtime.Objec
 	return retList, nil
 }
 
-func spaceConstraints(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func spaceConstraints(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	objs := &storagev1.SpaceConstraintList{}
-	err := client.List(ctx, objs)
+	err := client.List(ctx, objs, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -416,9 +329,9 @@ func spaceConstraints(ctx context.Context, client clientpkg.Client) ([]runtime.O
 	return retList, nil
 }
 
-func clusterAccess(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func clusterAccess(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	objs := &storagev1.ClusterAccessList{}
-	err := client.List(ctx, objs)
+	err := client.List(ctx, objs, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -438,9 +351,9 @@ func clusterAccess(ctx context.Context, client clientpkg.Client) ([]runtime.Obje
 	return retList, nil
 }
 
-func virtualClusterTemplate(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func virtualClusterTemplate(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	virtualClusterTemplates := &storagev1.VirtualClusterTemplateList{}
-	err := client.List(ctx, virtualClusterTemplates)
+	err := client.List(ctx, virtualClusterTemplates, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -460,9 +373,9 @@ func virtualClusterTemplate(ctx context.Context, client clientpkg.Client) ([]run
 	return retList, nil
 }
 
-func spaceTemplates(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func spaceTemplates(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	spaceTemplates := &storagev1.SpaceTemplateList{}
-	err := client.List(ctx, spaceTemplates)
+	err := client.List(ctx, spaceTemplates, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -482,9 +395,9 @@ func spaceTemplates(ctx context.Context, client clientpkg.Client) ([]runtime.Obj
 	return retList, nil
 }
 
-func devPodWorkspaceTemplate(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func devPodWorkspaceTemplate(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	devPodWorkspaceTemplates := &storagev1.DevPodWorkspaceTemplateList{}
-	err := client.List(ctx, devPodWorkspaceTemplates)
+	err := client.List(ctx, devPodWorkspaceTemplates, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -504,9 +417,50 @@ func devPodWorkspaceTemplate(ctx context.Context, client clientpkg.Client) ([]ru
 	return retList, nil
 }
 
-func apps(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+// templates returns the templates of the given kind with the given names
+func templates(ctx context.Context, client clientpkg.Client, kind string, names []string) ([]runtime.Object, error) {
+	retList := []runtime.Object{}
+	for _, name := range names {
+		var obj clientpkg.Object
+		switch kind {
+		case "VirtualClusterTemplate":
+			obj = &storagev1.VirtualClusterTemplate{}
+		case "SpaceTemplate":
+			obj = &storagev1.SpaceTemplate{}
+		case "DevPodWorkspaceTemplate":
+			obj = &storagev1.DevPodWorkspaceTemplate{}
+		default:
+			return nil, errors.Errorf("unsupported template kind %s", kind)
+		}
+
+		err := client.Get(ctx, clientpkg.ObjectKey{Name: name}, obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get %s %s", kind, name)
+		}
+
+		switch o := obj.(type) {
+		case *storagev1.VirtualClusterTemplate:
+			o.Status = storagev1.VirtualClusterTemplateStatus{}
+		case *storagev1.SpaceTemplate:
+			o.Status = storagev1.SpaceTemplateStatus{}
+		case *storagev1.DevPodWorkspaceTemplate:
+			o.Status = storagev1.DevPodWorkspaceTemplateStatus{}
+		}
+
+		err = resetMetadata(client.Scheme(), obj)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, obj)
+	}
+
+	return retList, nil
+}
+
+func apps(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	apps := &storagev1.AppList{}
-	err := client.List(ctx, apps)
+	err := client.List(ctx, apps, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -526,9 +480,9 @@ func apps(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error
 	return retList, nil
 }
 
-func accessKeys(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func accessKeys(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	accessKeyList := &storagev1.AccessKeyList{}
-	err := client.List(ctx, accessKeyList)
+	err := client.List(ctx, accessKeyList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -548,9 +502,9 @@ func accessKeys(ctx context.Context, client clientpkg.Client) ([]runtime.Object,
 	return retList, nil
 }
 
-func sharedSecrets(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func sharedSecrets(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	sharedSecretList := &storagev1.SharedSecretList{}
-	err := client.List(ctx, sharedSecretList, clientpkg.InNamespace(""))
+	err := client.List(ctx, sharedSecretList, append([]clientpkg.ListOption{clientpkg.InNamespace("")}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -570,9 +524,9 @@ func sharedSecrets(ctx context.Context, client clientpkg.Client) ([]runtime.Obje
 	return retList, nil
 }
 
-func teams(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func teams(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	teamList := &storagev1.TeamList{}
-	err := client.List(ctx, teamList)
+	err := client.List(ctx, teamList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -592,9 +546,9 @@ func teams(ctx context.Context, client clientpkg.Client) ([]runtime.Object, erro
 	return retList, nil
 }
 
-func users(ctx context.Context, client clientpkg.Client) ([]runtime.Object, error) {
+func users(ctx context.Context, client clientpkg.Client, opts ...clientpkg.ListOption) ([]runtime.Object, error) {
 	userList := &storagev1.UserList{}
-	err := client.List(ctx, userList)
+	err := client.List(ctx, userList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -634,7 +588,7 @@ func users(ctx context.Context, client clientpkg.Client) ([]runtime.Object, erro
 
 func isProjectSecret(secret corev1.Secret) bool {
 	for k, v := range secret.Labels {
-		if k == "loft.sh/project-secret" && v == "true" {
+		if k == projectSecretLabel && v == "true" {
 			return true
 		}
 	}
@@ -0,0 +1,187 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientpkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultConcurrency is the number of collectors that run in parallel by default
+const DefaultConcurrency = 4
+
+// Options holds the options for creating a backup
+type Options struct {
+	// Skip holds the resources that should not be backed up
+	Skip []string
+
+	// Only holds the resources that should be backed up, all if empty
+	Only []string
+
+	// Projects limits the backup to the given projects, their instances and secrets
+	// and the templates they reference
+	Projects []string
+
+	// Selector limits the backup to objects with matching labels
+	Selector labels.Selector
+
+	// Concurrency is the maximum number of collectors that run in parallel
+	Concurrency int
+
+	// Progress is called whenever a collector starts or finishes
+	Progress ProgressFn
+}
+
+// ProgressEvent describes the progress of a single collector
+type ProgressEvent struct {
+	// Resource is the resource the collector backs up, e.g. virtual cluster instances
+	Resource string
+
+	// Done is false when the collector starts and true once it finished
+	Done bool
+
+	// Count is the number of objects the collector backed up
+	Count int
+
+	// Duration is the time the collector took
+	Duration time.Duration
+
+	// Err is the error the collector failed with
+	Err error
+}
+
+// ProgressFn receives progress events. Calls are serialized, so implementations
+// don't need to be safe for concurrent use.
+type ProgressFn func(event ProgressEvent)
+
+// LogProgress returns a ProgressFn that writes the progress through the given LogFn
+func LogProgress(infoFn LogFn) ProgressFn {
+	return func(event ProgressEvent) {
+		if !event.Done {
+			infoFn(fmt.Sprintf("Backing up %s...", event.Resource))
+		} else if event.Err == nil {
+			infoFn(fmt.Sprintf("Backed up %d %s in %s", event.Count, event.Resource, event.Duration.Round(time.Millisecond)))
+		}
+	}
+}
+
+type collectFn func(ctx context.Context) ([]runtime.Object, error)
+
+// collector backs up a single resource. The name is the one used in Options.Skip.
+type collector struct {
+	name     string
+	resource string
+	collect  collectFn
+}
+
+// runCollectors runs the given collectors with bounded concurrency. Objects and errors are returned
+// in collector order, independent of the order the collectors finish in, so backups stay diffable.
+// A collector may return objects together with an error for partial results.
+func runCollectors(ctx context.Context, collectors []collector, options Options) ([]runtime.Object, []error) {
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	progressLock := sync.Mutex{}
+	progress := func(event ProgressEvent) {
+		if options.Progress == nil {
+			return
+		}
+
+		progressLock.Lock()
+		defer progressLock.Unlock()
+		options.Progress(event)
+	}
+
+	results := make([][]runtime.Object, len(collectors))
+	resultErrors := make([]error, len(collectors))
+	group := errgroup.Group{}
+	group.SetLimit(concurrency)
+	for i, c := range collectors {
+		i, c := i, c
+		group.Go(func() error {
+			progress(ProgressEvent{Resource: c.resource})
+
+			start := time.Now()
+			objs, err := c.collect(ctx)
+			if err != nil {
+				err = errors.Wrap(err, "backup "+c.resource)
+			}
+
+			results[i] = objs
+			resultErrors[i] = err
+			progress(ProgressEvent{
+				Resource: c.resource,
+				Done:     true,
+				Count:    len(objs),
+				Duration: time.Since(start),
+				Err:      err,
+			})
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	objects := []runtime.Object{}
+	backupErrors := []error{}
+	for i := range collectors {
+		objects = append(objects, results[i]...)
+		if resultErrors[i] != nil {
+			backupErrors = append(backupErrors, resultErrors[i])
+		}
+	}
+
+	return objects, backupErrors
+}
+
+// enabled checks if the resource with the given name should be backed up. If the backup is limited to
+// projects, only project resources are backed up unless other resources are requested explicitly.
+func (o Options) enabled(name string) bool {
+	if contains(o.Skip, name) {
+		return false
+	} else if len(o.Only) > 0 {
+		return contains(o.Only, name)
+	} else if len(o.Projects) > 0 {
+		return name == "projects" || contains(projectResources, name)
+	}
+
+	return true
+}
+
+// listOptions returns the list options for the host cluster client
+func (o Options) listOptions() []clientpkg.ListOption {
+	if o.Selector == nil || o.Selector.Empty() {
+		return nil
+	}
+
+	return []clientpkg.ListOption{clientpkg.MatchingLabelsSelector{Selector: o.Selector}}
+}
+
+// managementListOptions returns the list options for the management api
+func (o Options) managementListOptions() metav1.ListOptions {
+	if o.Selector == nil || o.Selector.Empty() {
+		return metav1.ListOptions{}
+	}
+
+	return metav1.ListOptions{LabelSelector: o.Selector.String()}
+}
+
+// filterCollectors removes all collectors that should not run
+func filterCollectors(collectors []collector, options Options) []collector {
+	retCollectors := []collector{}
+	for _, c := range collectors {
+		if options.enabled(c.name) {
+			retCollectors = append(retCollectors, c)
+		}
+	}
+
+	return retCollectors
+}
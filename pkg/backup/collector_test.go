@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRunCollectors(t *testing.T) {
+	collectors := []collector{}
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("collector-%d", i)
+		delay := time.Duration(5-i) * 10 * time.Millisecond
+		collectors = append(collectors, collector{
+			name:     name,
+			resource: name,
+			collect: func(ctx context.Context) ([]runtime.Object, error) {
+				time.Sleep(delay)
+				if name == "collector-3" {
+					return nil, fmt.Errorf("failed")
+				}
+
+				return []runtime.Object{&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name}}}, nil
+			},
+		})
+	}
+
+	counts := map[string]int{}
+	objects, errors := runCollectors(context.Background(), filterCollectors(collectors, Options{Skip: []string{"collector-1"}}), Options{
+		Concurrency: 3,
+		Progress: func(event ProgressEvent) {
+			if event.Done {
+				counts[event.Resource] = event.Count
+			}
+		},
+	})
+
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, obj.(*corev1.Secret).Name)
+	}
+	assert.DeepEqual(t, names, []string{"collector-0", "collector-2", "collector-4"})
+	assert.Equal(t, len(errors), 1)
+	assert.Error(t, errors[0], "backup collector-3: failed")
+	assert.DeepEqual(t, counts, map[string]int{"collector-0": 1, "collector-2": 1, "collector-3": 0, "collector-4": 1})
+}
+
+func TestOptionsEnabled(t *testing.T) {
+	options := Options{}
+	assert.Assert(t, options.enabled("users"))
+
+	options = Options{Skip: []string{"users"}}
+	assert.Assert(t, !options.enabled("users"))
+	assert.Assert(t, options.enabled("teams"))
+
+	options = Options{Only: []string{"virtualclusterinstances"}, Skip: []string{"users"}}
+	assert.Assert(t, options.enabled("virtualclusterinstances"))
+	assert.Assert(t, !options.enabled("teams"))
+
+	options = Options{Projects: []string{"default"}}
+	assert.Assert(t, options.enabled("projects"))
+	assert.Assert(t, options.enabled("spaceinstances"))
+	assert.Assert(t, !options.enabled("users"))
+	assert.Assert(t, !options.enabled("virtualclustertemplates"))
+}
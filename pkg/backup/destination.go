@@ -0,0 +1,198 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TimestampFormat is the format of the timestamp within timestamped backup names
+const TimestampFormat = "20060102T150405Z"
+
+// Destination stores backups
+type Destination interface {
+	// Write stores the backup with the given name
+	Write(ctx context.Context, name string, data []byte) error
+
+	// List returns the names of all stored backups
+	List(ctx context.Context) ([]string, error)
+
+	// Delete removes the backup with the given name
+	Delete(ctx context.Context, name string) error
+
+	// String returns a human readable location of the destination
+	String() string
+}
+
+// NewDestination parses the given destination. Supported are a local directory (file:///backups or
+// a plain path), stdout (-) and S3 compatible object storage (s3://bucket/prefix). S3 credentials are
+// read from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables,
+// the region from AWS_REGION or the region query parameter. A custom endpoint such as MinIO can be set
+// via the endpoint query parameter, e.g. s3://backups?endpoint=http://minio:9000&path-style=true.
+func NewDestination(destination string) (Destination, error) {
+	if destination == "-" {
+		return &StdoutDestination{Writer: os.Stdout}, nil
+	} else if !strings.Contains(destination, "://") {
+		return &FileDestination{Dir: destination}, nil
+	}
+
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse destination")
+	}
+
+	switch parsed.Scheme {
+	case "file":
+		return &FileDestination{Dir: parsed.Host + parsed.Path}, nil
+	case "s3":
+		return newS3DestinationFromURL(parsed)
+	default:
+		return nil, fmt.Errorf("unsupported destination scheme %s, needs to be either file or s3", parsed.Scheme)
+	}
+}
+
+// FileDestination stores backups in a local directory
+type FileDestination struct {
+	Dir string
+}
+
+func (f *FileDestination) Write(_ context.Context, name string, data []byte) error {
+	if f.Dir != "" {
+		err := os.MkdirAll(f.Dir, 0755)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(f.Dir, name), data, 0600)
+}
+
+func (f *FileDestination) List(_ context.Context) ([]string, error) {
+	dir := f.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, nil
+}
+
+func (f *FileDestination) Delete(_ context.Context, name string) error {
+	return os.Remove(filepath.Join(f.Dir, name))
+}
+
+func (f *FileDestination) String() string {
+	if f.Dir == "" {
+		return "."
+	}
+
+	return f.Dir
+}
+
+// StdoutDestination writes backups to the given writer. It doesn't support retention.
+type StdoutDestination struct {
+	Writer io.Writer
+}
+
+func (s *StdoutDestination) Write(_ context.Context, _ string, data []byte) error {
+	_, err := s.Writer.Write(data)
+	return err
+}
+
+func (s *StdoutDestination) List(_ context.Context) ([]string, error) {
+	return nil, fmt.Errorf("listing backups is not supported for stdout")
+}
+
+func (s *StdoutDestination) Delete(_ context.Context, _ string) error {
+	return fmt.Errorf("deleting backups is not supported for stdout")
+}
+
+func (s *StdoutDestination) String() string {
+	return "stdout"
+}
+
+// TimestampedName inserts the given time before the extension of the name,
+// e.g. backup.tar.gz becomes backup-20240101T000000Z.tar.gz
+func TimestampedName(name string, t time.Time) string {
+	stem, ext := splitExt(name)
+	return stem + "-" + t.UTC().Format(TimestampFormat) + ext
+}
+
+// ApplyRetention deletes all but the newest keep timestamped versions of the given backup name
+// from the destination and returns the deleted names
+func ApplyRetention(ctx context.Context, destination Destination, name string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+
+	names, err := destination.List(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "list backups")
+	}
+
+	stem, ext := splitExt(name)
+	backups := []string{}
+	for _, n := range names {
+		if !strings.HasPrefix(n, stem+"-") || !strings.HasSuffix(n, ext) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(n, stem+"-"), ext)
+		if _, err := time.Parse(TimestampFormat, timestamp); err == nil {
+			backups = append(backups, n)
+		}
+	}
+	if len(backups) <= keep {
+		return nil, nil
+	}
+
+	// the timestamp format sorts lexically, so the oldest backups come first
+	sort.Strings(backups)
+	deleted := []string{}
+	for _, n := range backups[:len(backups)-keep] {
+		err := destination.Delete(ctx, n)
+		if err != nil {
+			return deleted, errors.Wrapf(err, "delete backup %s", n)
+		}
+
+		deleted = append(deleted, n)
+	}
+
+	return deleted, nil
+}
+
+func splitExt(name string) (string, string) {
+	for _, ext := range []string{".tar.gz", ".yaml", ".yml", ".json"} {
+		if strings.HasSuffix(name, ext) {
+			return strings.TrimSuffix(name, ext), ext
+		}
+	}
+
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext), ext
+}
+
+func parseBool(value string) bool {
+	b, _ := strconv.ParseBool(value)
+	return b
+}
@@ -0,0 +1,265 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DiffType describes how an object changed between two backups
+type DiffType string
+
+const (
+	DiffTypeAdded   DiffType = "added"
+	DiffTypeRemoved DiffType = "removed"
+	DiffTypeChanged DiffType = "changed"
+)
+
+// maskedValue replaces secret values in field diffs
+const maskedValue = "<masked>"
+
+// ObjectDiff is a single object that differs between two backups
+type ObjectDiff struct {
+	Type      DiffType
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+
+	// Fields holds the changed fields, only set for changed objects
+	Fields []FieldDiff
+}
+
+// DisplayName returns the kind and namespaced name of the object
+func (o ObjectDiff) DisplayName() string {
+	if o.Namespace == "" {
+		return o.GVK.Kind + " " + o.Name
+	}
+
+	return o.GVK.Kind + " " + o.Namespace + "/" + o.Name
+}
+
+// FieldDiff is a single changed field of an object. Old and New are empty if the field was added or removed.
+type FieldDiff struct {
+	Path string
+	Old  string
+	New  string
+}
+
+type diffObject struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+	content   map[string]interface{}
+}
+
+// Diff matches the objects of two backups by group version kind, namespace and name
+// and returns all added, removed and changed objects sorted by kind, namespace and name.
+// Values of secrets are masked in the field diffs.
+func Diff(scheme *runtime.Scheme, oldObjects, newObjects []runtime.Object) ([]ObjectDiff, error) {
+	oldMap, err := diffObjects(scheme, oldObjects)
+	if err != nil {
+		return nil, errors.Wrap(err, "old backup")
+	}
+	newMap, err := diffObjects(scheme, newObjects)
+	if err != nil {
+		return nil, errors.Wrap(err, "new backup")
+	}
+
+	diffs := []ObjectDiff{}
+	for key, oldObj := range oldMap {
+		newObj, ok := newMap[key]
+		if !ok {
+			diffs = append(diffs, ObjectDiff{Type: DiffTypeRemoved, GVK: oldObj.gvk, Namespace: oldObj.namespace, Name: oldObj.name})
+			continue
+		}
+
+		fields := []FieldDiff{}
+		diffValues("", oldObj.content, newObj.content, secretPaths(oldObj.gvk), &fields)
+		if len(fields) > 0 {
+			diffs = append(diffs, ObjectDiff{Type: DiffTypeChanged, GVK: oldObj.gvk, Namespace: oldObj.namespace, Name: oldObj.name, Fields: fields})
+		}
+	}
+	for key, newObj := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			diffs = append(diffs, ObjectDiff{Type: DiffTypeAdded, GVK: newObj.gvk, Namespace: newObj.namespace, Name: newObj.name})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].GVK.String() != diffs[j].GVK.String() {
+			return diffs[i].GVK.String() < diffs[j].GVK.String()
+		} else if diffs[i].Namespace != diffs[j].Namespace {
+			return diffs[i].Namespace < diffs[j].Namespace
+		}
+
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs, nil
+}
+
+// diffObjects normalizes the given objects and indexes them by group version kind, namespace and name
+func diffObjects(scheme *runtime.Scheme, objects []runtime.Object) (map[string]*diffObject, error) {
+	retMap := map[string]*diffObject{}
+	for _, o := range objects {
+		obj := o.DeepCopyObject()
+		err := resetMetadata(scheme, obj)
+		if err != nil {
+			return nil, err
+		}
+
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		} else if accessor.GetName() == "" {
+			// empty objects are written for secrets that could not be found during backup
+			continue
+		}
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, errors.Wrap(err, "convert object")
+		}
+
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		key := gvk.String() + "/" + accessor.GetNamespace() + "/" + accessor.GetName()
+		retMap[key] = &diffObject{
+			gvk:       gvk,
+			namespace: accessor.GetNamespace(),
+			name:      accessor.GetName(),
+			content:   content,
+		}
+	}
+
+	return retMap, nil
+}
+
+func diffValues(path string, oldValue, newValue interface{}, secrets []string, fields *[]FieldDiff) {
+	if reflect.DeepEqual(oldValue, newValue) {
+		return
+	}
+
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := []string{}
+		for key := range oldMap {
+			keys = append(keys, key)
+		}
+		for key := range newMap {
+			if _, ok := oldMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			diffValues(joinPath(path, key), oldMap[key], newMap[key], secrets, fields)
+		}
+		return
+	}
+
+	oldSlice, oldIsSlice := oldValue.([]interface{})
+	newSlice, newIsSlice := newValue.([]interface{})
+	if oldIsSlice && newIsSlice && len(oldSlice) == len(newSlice) {
+		for i := range oldSlice {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), oldSlice[i], newSlice[i], secrets, fields)
+		}
+		return
+	}
+
+	*fields = append(*fields, FieldDiff{
+		Path: path,
+		Old:  renderValue(path, oldValue, secrets),
+		New:  renderValue(path, newValue, secrets),
+	})
+}
+
+// renderValue renders the value as json with all secret fields within it masked
+func renderValue(path string, value interface{}, secrets []string) string {
+	if value == nil {
+		return ""
+	} else if isSecretPath(path, secrets) {
+		return maskedValue
+	}
+
+	// the masked marker is not escaped, so it reads the same as a masked field
+	out := &bytes.Buffer{}
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(maskValue(path, value, secrets))
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// maskValue returns a copy of the value whose nested secret fields are masked, which is
+// needed if a parent of a secret field, such as spec, was added or removed as a whole
+func maskValue(path string, value interface{}, secrets []string) interface{} {
+	if isSecretPath(path, secrets) {
+		return maskedValue
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[key] = maskValue(joinPath(path, key), child, secrets)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = maskValue(fmt.Sprintf("%s[%d]", path, i), child, secrets)
+		}
+		return out
+	}
+
+	return value
+}
+
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		return path + "[" + key + "]"
+	} else if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// secretPaths returns the fields of the kind that hold secret values, which covers data and
+// stringData of secrets, including the ones project secrets are stored as, spec.data of shared
+// and project secrets and the key of access keys
+func secretPaths(gvk schema.GroupVersionKind) []string {
+	switch gvk.Kind {
+	case "Secret":
+		return []string{"data", "stringData"}
+	case "SharedSecret", "ProjectSecret":
+		return []string{"spec.data"}
+	case "AccessKey", "OwnedAccessKey":
+		return []string{"spec.key"}
+	}
+
+	return nil
+}
+
+// isSecretPath checks if the field is one of the secret fields or within one of them
+func isSecretPath(path string, secrets []string) bool {
+	for _, prefix := range secrets {
+		if path == prefix || strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+package backup
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestDiff(t *testing.T) {
+	oldObjects := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "changed", Namespace: "loft", ResourceVersion: "1"},
+			Data:       map[string][]byte{"password": []byte("old"), "user": []byte("admin")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "removed", Namespace: "loft"},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "loft", Labels: map[string]string{"loft.sh/name": "old"}},
+		},
+	}
+	newObjects := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "changed", Namespace: "loft", ResourceVersion: "2"},
+			Data:       map[string][]byte{"password": []byte("new"), "user": []byte("admin")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "added", Namespace: "loft"},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "loft", Labels: map[string]string{"loft.sh/name": "new"}},
+		},
+	}
+
+	diffs, err := Diff(clientgoscheme.Scheme, oldObjects, newObjects)
+	assert.NilError(t, err)
+	assert.Equal(t, len(diffs), 4)
+
+	assert.Equal(t, diffs[0].DisplayName(), "Namespace loft")
+	assert.DeepEqual(t, diffs[0].Fields, []FieldDiff{{Path: "metadata.labels[loft.sh/name]", Old: `"old"`, New: `"new"`}})
+
+	assert.Equal(t, diffs[1].DisplayName(), "Secret loft/added")
+	assert.Equal(t, diffs[1].Type, DiffTypeAdded)
+
+	assert.Equal(t, diffs[2].DisplayName(), "Secret loft/changed")
+	assert.DeepEqual(t, diffs[2].Fields, []FieldDiff{{Path: "data.password", Old: maskedValue, New: maskedValue}})
+
+	assert.Equal(t, diffs[3].DisplayName(), "Secret loft/removed")
+	assert.Equal(t, diffs[3].Type, DiffTypeRemoved)
+}
+
+func TestDiffAccessKey(t *testing.T) {
+	accessKey := func(name string, spec map[string]interface{}) runtime.Object {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "storage.loft.sh/v1",
+			"kind":       "AccessKey",
+			"metadata":   map[string]interface{}{"name": name},
+		}}
+		if spec != nil {
+			obj.Object["spec"] = spec
+		}
+
+		return obj
+	}
+
+	oldObjects := []runtime.Object{
+		accessKey("changed", map[string]interface{}{"key": "old-key", "user": "admin", "displayName": "old"}),
+		accessKey("spec-added", nil),
+	}
+	newObjects := []runtime.Object{
+		accessKey("changed", map[string]interface{}{"key": "new-key", "user": "admin", "displayName": "new"}),
+		accessKey("spec-added", map[string]interface{}{"key": "added-key", "user": "admin"}),
+	}
+
+	diffs, err := Diff(clientgoscheme.Scheme, oldObjects, newObjects)
+	assert.NilError(t, err)
+	assert.Equal(t, len(diffs), 2)
+
+	assert.Equal(t, diffs[0].DisplayName(), "AccessKey changed")
+	assert.DeepEqual(t, diffs[0].Fields, []FieldDiff{
+		{Path: "spec.displayName", Old: `"old"`, New: `"new"`},
+		{Path: "spec.key", Old: maskedValue, New: maskedValue},
+	})
+
+	assert.Equal(t, diffs[1].DisplayName(), "AccessKey spec-added")
+	assert.DeepEqual(t, diffs[1].Fields, []FieldDiff{
+		{Path: "spec", Old: "", New: `{"key":"<masked>","user":"admin"}`},
+	})
+}
@@ -0,0 +1,467 @@
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// EncryptionScopeSecrets only encrypts the values of secrets within the backup
+	EncryptionScopeSecrets = "secrets"
+	// EncryptionScopeFile encrypts the whole backup file
+	EncryptionScopeFile = "file"
+
+	// EncryptionAnnotation is set on encrypted secrets and holds the wrapped key to decrypt the values
+	EncryptionAnnotation = "backup.loft.sh/encryption"
+
+	encryptedFileKind    = "EncryptedBackup"
+	encryptedFileVersion = 1
+
+	keyMethodPassphrase = "passphrase"
+	keyMethodRSA        = "rsa-oaep-sha256"
+)
+
+// EncryptionKey holds the key material to encrypt or decrypt a backup. For encryption
+// either a passphrase or a public key is required, for decryption either a passphrase
+// or a private key.
+type EncryptionKey struct {
+	Passphrase string
+	PublicKey  *rsa.PublicKey
+	PrivateKey *rsa.PrivateKey
+}
+
+// keyHeader describes how the data key of a backup was wrapped
+type keyHeader struct {
+	Method     string `json:"method"`
+	Salt       []byte `json:"salt,omitempty"`
+	WrappedKey []byte `json:"wrappedKey"`
+}
+
+type encryptedFile struct {
+	Kind    string    `json:"kind"`
+	Version int       `json:"version"`
+	Key     keyHeader `json:"key"`
+	Data    []byte    `json:"data"`
+}
+
+// Encrypter encrypts backups with a random data key, that is wrapped with the given key
+type Encrypter struct {
+	dataKey       []byte
+	header        keyHeader
+	encodedHeader string
+}
+
+// NewEncrypter creates a new encrypter for the given key
+func NewEncrypter(key EncryptionKey) (*Encrypter, error) {
+	dataKey := make([]byte, 32)
+	_, err := io.ReadFull(rand.Reader, dataKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "generate data key")
+	}
+
+	header := keyHeader{}
+	switch {
+	case key.PublicKey != nil:
+		header.Method = keyMethodRSA
+		header.WrappedKey, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, key.PublicKey, dataKey, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "wrap data key")
+		}
+	case key.Passphrase != "":
+		header.Method = keyMethodPassphrase
+		header.Salt = make([]byte, 16)
+		_, err = io.ReadFull(rand.Reader, header.Salt)
+		if err != nil {
+			return nil, errors.Wrap(err, "generate salt")
+		}
+
+		passphraseKey, err := derivePassphraseKey(key.Passphrase, header.Salt)
+		if err != nil {
+			return nil, err
+		}
+
+		header.WrappedKey, err = encryptBytes(passphraseKey, dataKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "wrap data key")
+		}
+	default:
+		return nil, errors.New("either a passphrase or a public key is required to encrypt a backup")
+	}
+
+	encodedHeader, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Encrypter{
+		dataKey:       dataKey,
+		header:        header,
+		encodedHeader: base64.StdEncoding.EncodeToString(encodedHeader),
+	}, nil
+}
+
+// EncryptSecrets encrypts the values of all secrets and shared secrets within objects
+func (e *Encrypter) EncryptSecrets(objects []runtime.Object) error {
+	for _, obj := range objects {
+		switch secret := obj.(type) {
+		case *corev1.Secret:
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			for k, v := range secret.StringData {
+				secret.Data[k] = []byte(v)
+			}
+			secret.StringData = nil
+
+			err := e.encryptValues(secret.Data)
+			if err != nil {
+				return errors.Wrapf(err, "encrypt secret %s/%s", secret.Namespace, secret.Name)
+			}
+
+			secret.Annotations = e.annotate(secret.Annotations)
+		case *storagev1.SharedSecret:
+			err := e.encryptValues(secret.Spec.Data)
+			if err != nil {
+				return errors.Wrapf(err, "encrypt shared secret %s/%s", secret.Namespace, secret.Name)
+			}
+
+			secret.Annotations = e.annotate(secret.Annotations)
+		}
+	}
+
+	return nil
+}
+
+// EncryptFile encrypts the complete backup
+func (e *Encrypter) EncryptFile(data []byte) ([]byte, error) {
+	encryptedData, err := encryptBytes(e.dataKey, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(&encryptedFile{
+		Kind:    encryptedFileKind,
+		Version: encryptedFileVersion,
+		Key:     e.header,
+		Data:    encryptedData,
+	}, "", "  ")
+}
+
+func (e *Encrypter) encryptValues(values map[string][]byte) error {
+	for k, v := range values {
+		encryptedValue, err := encryptBytes(e.dataKey, v)
+		if err != nil {
+			return err
+		}
+
+		values[k] = encryptedValue
+	}
+
+	return nil
+}
+
+func (e *Encrypter) annotate(annotations map[string]string) map[string]string {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[EncryptionAnnotation] = e.encodedHeader
+	return annotations
+}
+
+// Decrypter decrypts backups created by an Encrypter
+type Decrypter struct {
+	key      EncryptionKey
+	dataKeys map[string][]byte
+}
+
+// NewDecrypter creates a new decrypter for the given key
+func NewDecrypter(key EncryptionKey) *Decrypter {
+	return &Decrypter{
+		key:      key,
+		dataKeys: map[string][]byte{},
+	}
+}
+
+// IsEncryptedFile returns true if the whole backup was encrypted
+func IsEncryptedFile(data []byte) bool {
+	file := &encryptedFile{}
+	err := json.Unmarshal(data, file)
+	return err == nil && file.Kind == encryptedFileKind
+}
+
+// HasEncryptedSecrets returns true if any of the objects holds encrypted values
+func HasEncryptedSecrets(objects []runtime.Object) bool {
+	for _, obj := range objects {
+		switch secret := obj.(type) {
+		case *corev1.Secret:
+			if secret.Annotations[EncryptionAnnotation] != "" {
+				return true
+			}
+		case *storagev1.SharedSecret:
+			if secret.Annotations[EncryptionAnnotation] != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// DecryptFile decrypts a backup that was encrypted as a whole
+func (d *Decrypter) DecryptFile(data []byte) ([]byte, error) {
+	file := &encryptedFile{}
+	err := json.Unmarshal(data, file)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse encrypted backup")
+	} else if file.Kind != encryptedFileKind {
+		return nil, errors.New("backup is not encrypted")
+	} else if file.Version != encryptedFileVersion {
+		return nil, fmt.Errorf("unsupported encrypted backup version %d", file.Version)
+	}
+
+	dataKey, err := d.unwrapKey(file.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptBytes(dataKey, file.Data)
+}
+
+// DecryptSecrets decrypts the values of all encrypted secrets and shared secrets within objects
+func (d *Decrypter) DecryptSecrets(objects []runtime.Object) error {
+	for _, obj := range objects {
+		switch secret := obj.(type) {
+		case *corev1.Secret:
+			err := d.decryptValues(secret.Annotations, secret.Data)
+			if err != nil {
+				return errors.Wrapf(err, "decrypt secret %s/%s", secret.Namespace, secret.Name)
+			}
+
+			delete(secret.Annotations, EncryptionAnnotation)
+		case *storagev1.SharedSecret:
+			err := d.decryptValues(secret.Annotations, secret.Spec.Data)
+			if err != nil {
+				return errors.Wrapf(err, "decrypt shared secret %s/%s", secret.Namespace, secret.Name)
+			}
+
+			delete(secret.Annotations, EncryptionAnnotation)
+		}
+	}
+
+	return nil
+}
+
+func (d *Decrypter) decryptValues(annotations map[string]string, values map[string][]byte) error {
+	encodedHeader := annotations[EncryptionAnnotation]
+	if encodedHeader == "" {
+		return nil
+	}
+
+	dataKey, ok := d.dataKeys[encodedHeader]
+	if !ok {
+		rawHeader, err := base64.StdEncoding.DecodeString(encodedHeader)
+		if err != nil {
+			return errors.Wrap(err, "decode encryption annotation")
+		}
+
+		header := keyHeader{}
+		err = json.Unmarshal(rawHeader, &header)
+		if err != nil {
+			return errors.Wrap(err, "parse encryption annotation")
+		}
+
+		dataKey, err = d.unwrapKey(header)
+		if err != nil {
+			return err
+		}
+
+		d.dataKeys[encodedHeader] = dataKey
+	}
+
+	for k, v := range values {
+		decryptedValue, err := decryptBytes(dataKey, v)
+		if err != nil {
+			return errors.Wrapf(err, "decrypt key %s", k)
+		}
+
+		values[k] = decryptedValue
+	}
+
+	return nil
+}
+
+func (d *Decrypter) unwrapKey(header keyHeader) ([]byte, error) {
+	switch header.Method {
+	case keyMethodRSA:
+		if d.key.PrivateKey == nil {
+			return nil, errors.New("backup was encrypted with a public key, please specify the matching private key")
+		}
+
+		dataKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, d.key.PrivateKey, header.WrappedKey, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "unwrap data key")
+		}
+
+		return dataKey, nil
+	case keyMethodPassphrase:
+		if d.key.Passphrase == "" {
+			return nil, errors.New("backup was encrypted with a passphrase, please specify the passphrase")
+		}
+
+		passphraseKey, err := derivePassphraseKey(d.key.Passphrase, header.Salt)
+		if err != nil {
+			return nil, err
+		}
+
+		dataKey, err := decryptBytes(passphraseKey, header.WrappedKey)
+		if err != nil {
+			return nil, errors.New("unwrap data key: wrong passphrase")
+		}
+
+		return dataKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported key method %q", header.Method)
+	}
+}
+
+// LoadPublicKey loads a PEM encoded RSA public key or certificate
+func LoadPublicKey(path string) (*rsa.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var publicKey interface{}
+	switch block.Type {
+	case "CERTIFICATE":
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse certificate")
+		}
+
+		publicKey = certificate.PublicKey
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse public key")
+		}
+	default:
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse public key")
+		}
+	}
+
+	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain a RSA public key", path)
+	}
+
+	return rsaPublicKey, nil
+}
+
+// LoadPrivateKey loads a PEM encoded RSA private key
+func LoadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse private key")
+		}
+
+		return privateKey, nil
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse private key")
+	}
+
+	rsaPrivateKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain a RSA private key", path)
+	}
+
+	return rsaPrivateKey, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	out, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(out)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not PEM encoded", path)
+	}
+
+	return block, nil
+}
+
+func derivePassphraseKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "derive key from passphrase")
+	}
+
+	return key, nil
+}
+
+func encryptBytes(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, errors.Wrap(err, "generate nonce")
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decryptBytes(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	return gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
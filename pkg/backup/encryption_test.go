@@ -0,0 +1,70 @@
+package backup
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestEncryption(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+
+	testCases := []struct {
+		name          string
+		encryptionKey EncryptionKey
+		decryptionKey EncryptionKey
+	}{
+		{
+			name:          "passphrase",
+			encryptionKey: EncryptionKey{Passphrase: "test"},
+			decryptionKey: EncryptionKey{Passphrase: "test"},
+		},
+		{
+			name:          "rsa",
+			encryptionKey: EncryptionKey{PublicKey: &privateKey.PublicKey},
+			decryptionKey: EncryptionKey{PrivateKey: privateKey},
+		},
+	}
+
+	for _, testCase := range testCases {
+		encrypter, err := NewEncrypter(testCase.encryptionKey)
+		assert.NilError(t, err, testCase.name)
+
+		secret := &corev1.Secret{
+			Data:       map[string][]byte{"password": []byte("secret")},
+			StringData: map[string]string{"token": "token"},
+		}
+		objects := []runtime.Object{secret}
+		assert.NilError(t, encrypter.EncryptSecrets(objects), testCase.name)
+		assert.Assert(t, string(secret.Data["password"]) != "secret", testCase.name)
+		assert.Assert(t, HasEncryptedSecrets(objects), testCase.name)
+
+		decrypter := NewDecrypter(testCase.decryptionKey)
+		assert.NilError(t, decrypter.DecryptSecrets(objects), testCase.name)
+		assert.DeepEqual(t, secret.Data, map[string][]byte{"password": []byte("secret"), "token": []byte("token")})
+
+		out, err := encrypter.EncryptFile([]byte("backup"))
+		assert.NilError(t, err, testCase.name)
+		assert.Assert(t, IsEncryptedFile(out), testCase.name)
+
+		plain, err := decrypter.DecryptFile(out)
+		assert.NilError(t, err, testCase.name)
+		assert.Equal(t, string(plain), "backup", testCase.name)
+	}
+}
+
+func TestEncryptionWrongPassphrase(t *testing.T) {
+	encrypter, err := NewEncrypter(EncryptionKey{Passphrase: "test"})
+	assert.NilError(t, err)
+
+	out, err := encrypter.EncryptFile([]byte("backup"))
+	assert.NilError(t, err)
+
+	_, err = NewDecrypter(EncryptionKey{Passphrase: "other"}).DecryptFile(out)
+	assert.ErrorContains(t, err, "wrong passphrase")
+}
@@ -0,0 +1,631 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	managementv1 "github.com/loft-sh/api/v4/pkg/apis/management/v1"
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/loft-sh/loftctl/v4/pkg/kube"
+	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ManagementSkipped holds the resources AllFromManagement can't back up
+var ManagementSkipped = []string{"accesskeys"}
+
+// labels and annotations of the secrets project secrets are stored as in the project namespace
+const (
+	projectSecretLabel                 = "loft.sh/project-secret"
+	projectSecretNameLabel             = "loft.sh/project-secret-name"
+	projectSecretDisplayNameAnnotation = "loft.sh/project-secret-displayname"
+	projectSecretDescriptionAnnotation = "loft.sh/project-secret-description"
+	projectSecretOwnerAnnotation       = "loft.sh/project-secret-owner"
+	projectSecretAccessAnnotation      = "loft.sh/project-secret-access"
+)
+
+// AllFromManagement gathers the same objects as All, but reads them through the management api
+// instead of the host cluster. Secrets that are only stored in the host cluster, such as user
+// passwords and cluster configs, can't be read this way and are reported as errors. Access keys
+// are not part of the management api, so they are left out and reported as an error as well.
+// The returned objects are converted to their storage representation, so they can be restored
+// the same way as a backup created by All.
+func AllFromManagement(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, options Options) ([]runtime.Object, []error) {
+	listOptions := options.managementListOptions()
+	return runPlan(ctx, backupPlan{
+		collectors: []collector{
+			{name: "clusterroletemplates", resource: "clusterrole templates", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementClusterRoles(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "clusteraccesses", resource: "cluster accesses", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementClusterAccess(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "spaceconstraints", resource: "space constraints", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementSpaceConstraints(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "users", resource: "users", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				objs, err := managementUsers(ctx, managementClient, scheme, listOptions)
+				if err != nil {
+					return nil, err
+				} else if skipped := countSecretRefs(objs); skipped > 0 {
+					return objs, fmt.Errorf("%d password or code secret(s) are only stored in the host cluster and were not backed up", skipped)
+				}
+
+				return objs, nil
+			}},
+			{name: "teams", resource: "teams", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementTeams(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "sharedsecrets", resource: "shared secrets", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementSharedSecrets(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "accesskeys", resource: "access keys", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return nil, fmt.Errorf("access keys are only stored in the host cluster and were not backed up")
+			}},
+			{name: "apps", resource: "apps", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementApps(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "spacetemplates", resource: "space templates", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementSpaceTemplates(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "virtualclustertemplates", resource: "virtual cluster templates", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementVirtualClusterTemplates(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "devpodworkspacetemplates", resource: "devpod workspace templates", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementDevPodWorkspaceTemplates(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "clusters", resource: "clusters", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				objs, err := managementClusters(ctx, managementClient, scheme, listOptions)
+				if err != nil {
+					return nil, err
+				} else if skipped := countSecretRefs(objs); skipped > 0 {
+					return objs, fmt.Errorf("%d cluster config secret(s) are only stored in the host cluster and were not backed up", skipped)
+				}
+
+				return objs, nil
+			}},
+			{name: "runners", resource: "runners", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementRunners(ctx, managementClient, scheme, listOptions)
+			}},
+			{name: "projects", resource: "projects", collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return managementProjects(ctx, managementClient, scheme, listOptions)
+			}},
+		},
+		projectCollectors: func(projects []string) []collector {
+			return []collector{
+				{name: "virtualclusterinstances", resource: "virtual cluster instances", collect: func(ctx context.Context) ([]runtime.Object, error) {
+					return managementVirtualClusterInstances(ctx, managementClient, scheme, projects, listOptions)
+				}},
+				{name: "devpodworkspaceinstances", resource: "devpod workspace instances", collect: func(ctx context.Context) ([]runtime.Object, error) {
+					return managementDevPodWorkspaceInstances(ctx, managementClient, scheme, projects, listOptions)
+				}},
+				{name: "spaceinstances", resource: "space instances", collect: func(ctx context.Context) ([]runtime.Object, error) {
+					return managementSpaceInstances(ctx, managementClient, scheme, projects, listOptions)
+				}},
+				{name: "projectsecrets", resource: "project secrets", collect: func(ctx context.Context) ([]runtime.Object, error) {
+					return managementProjectSecrets(ctx, managementClient, scheme, projects, listOptions)
+				}},
+			}
+		},
+		listProjects: func(ctx context.Context) ([]string, error) {
+			objs, err := managementProjects(ctx, managementClient, scheme, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			return projectNames(objs), nil
+		},
+		getTemplates: func(ctx context.Context, kind string, names []string) ([]runtime.Object, error) {
+			return managementTemplates(ctx, managementClient, scheme, kind, names)
+		},
+	}, options)
+}
+
+// countSecretRefs counts the host cluster secrets referenced by the given users and clusters
+func countSecretRefs(objects []runtime.Object) int {
+	count := 0
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *storagev1.User:
+			if o.Spec.PasswordRef != nil && o.Spec.PasswordRef.SecretName != "" {
+				count++
+			}
+			if o.Spec.CodesRef != nil && o.Spec.CodesRef.SecretName != "" {
+				count++
+			}
+		case *storagev1.Cluster:
+			if o.Spec.Config.SecretName != "" {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+func managementProjects(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	projectList, err := managementClient.Loft().ManagementV1().Projects().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range projectList.Items {
+		u := &storagev1.Project{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.ProjectSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementVirtualClusterInstances(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, projects []string, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	retList := []runtime.Object{}
+	for _, projectName := range projects {
+		virtualClusterInstanceList, err := managementClient.Loft().ManagementV1().VirtualClusterInstances(projectutil.ProjectNamespace(projectName)).List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range virtualClusterInstanceList.Items {
+			u := &storagev1.VirtualClusterInstance{
+				ObjectMeta: o.ObjectMeta,
+				Spec:       o.Spec.VirtualClusterInstanceSpec,
+			}
+			err := resetMetadata(scheme, u)
+			if err != nil {
+				return nil, err
+			}
+
+			retList = append(retList, u)
+		}
+	}
+
+	return retList, nil
+}
+
+func managementDevPodWorkspaceInstances(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, projects []string, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	retList := []runtime.Object{}
+	for _, projectName := range projects {
+		devPodWorkspaceInstanceList, err := managementClient.Loft().ManagementV1().DevPodWorkspaceInstances(projectutil.ProjectNamespace(projectName)).List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range devPodWorkspaceInstanceList.Items {
+			u := &storagev1.DevPodWorkspaceInstance{
+				ObjectMeta: o.ObjectMeta,
+				Spec:       o.Spec.DevPodWorkspaceInstanceSpec,
+			}
+			err := resetMetadata(scheme, u)
+			if err != nil {
+				return nil, err
+			}
+
+			retList = append(retList, u)
+		}
+	}
+
+	return retList, nil
+}
+
+func managementSpaceInstances(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, projects []string, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	retList := []runtime.Object{}
+	for _, projectName := range projects {
+		spaceInstanceList, err := managementClient.Loft().ManagementV1().SpaceInstances(projectutil.ProjectNamespace(projectName)).List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range spaceInstanceList.Items {
+			u := &storagev1.SpaceInstance{
+				ObjectMeta: o.ObjectMeta,
+				Spec:       o.Spec.SpaceInstanceSpec,
+			}
+			err := resetMetadata(scheme, u)
+			if err != nil {
+				return nil, err
+			}
+
+			retList = append(retList, u)
+		}
+	}
+
+	return retList, nil
+}
+
+func managementProjectSecrets(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, projects []string, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	retList := []runtime.Object{}
+	for _, projectName := range projects {
+		projectSecretList, err := managementClient.Loft().ManagementV1().ProjectSecrets(projectutil.ProjectNamespace(projectName)).List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range projectSecretList.Items {
+			u, err := projectSecretToSecret(&o)
+			if err != nil {
+				return nil, err
+			}
+
+			err = resetMetadata(scheme, u)
+			if err != nil {
+				return nil, err
+			}
+
+			retList = append(retList, u)
+		}
+	}
+
+	return retList, nil
+}
+
+// projectSecretToSecret converts the project secret into the secret in the project namespace it's
+// stored as, with the same labels and annotations as the secrets read from the host cluster
+func projectSecretToSecret(projectSecret *managementv1.ProjectSecret) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: *projectSecret.ObjectMeta.DeepCopy(),
+		Data:       projectSecret.Spec.Data,
+	}
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+
+	secret.Labels[projectSecretLabel] = "true"
+	secret.Labels[projectSecretNameLabel] = projectSecret.Name
+	if projectSecret.Spec.DisplayName != "" {
+		secret.Annotations[projectSecretDisplayNameAnnotation] = projectSecret.Spec.DisplayName
+	}
+	if projectSecret.Spec.Description != "" {
+		secret.Annotations[projectSecretDescriptionAnnotation] = projectSecret.Spec.Description
+	}
+	if projectSecret.Spec.Owner != nil {
+		owner, err := json.Marshal(projectSecret.Spec.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("marshal owner of project secret %s: %w", projectSecret.Name, err)
+		}
+
+		secret.Annotations[projectSecretOwnerAnnotation] = string(owner)
+	}
+	if len(projectSecret.Spec.Access) > 0 {
+		access, err := json.Marshal(projectSecret.Spec.Access)
+		if err != nil {
+			return nil, fmt.Errorf("marshal access of project secret %s: %w", projectSecret.Name, err)
+		}
+
+		secret.Annotations[projectSecretAccessAnnotation] = string(access)
+	}
+
+	return secret, nil
+}
+
+// managementTemplates returns the templates of the given kind with the given names
+func managementTemplates(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, kind string, names []string) ([]runtime.Object, error) {
+	retList := []runtime.Object{}
+	for _, name := range names {
+		var obj runtime.Object
+		switch kind {
+		case "VirtualClusterTemplate":
+			template, err := managementClient.Loft().ManagementV1().VirtualClusterTemplates().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("get %s %s: %w", kind, name, err)
+			}
+
+			obj = &storagev1.VirtualClusterTemplate{ObjectMeta: template.ObjectMeta, Spec: template.Spec.VirtualClusterTemplateSpec}
+		case "SpaceTemplate":
+			template, err := managementClient.Loft().ManagementV1().SpaceTemplates().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("get %s %s: %w", kind, name, err)
+			}
+
+			obj = &storagev1.SpaceTemplate{ObjectMeta: template.ObjectMeta, Spec: template.Spec.SpaceTemplateSpec}
+		case "DevPodWorkspaceTemplate":
+			template, err := managementClient.Loft().ManagementV1().DevPodWorkspaceTemplates().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("get %s %s: %w", kind, name, err)
+			}
+
+			obj = &storagev1.DevPodWorkspaceTemplate{ObjectMeta: template.ObjectMeta, Spec: template.Spec.DevPodWorkspaceTemplateSpec}
+		default:
+			return nil, fmt.Errorf("unsupported template kind %s", kind)
+		}
+
+		err := resetMetadata(scheme, obj)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, obj)
+	}
+
+	return retList, nil
+}
+
+func managementClusters(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	clusterList, err := managementClient.Loft().ManagementV1().Clusters().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range clusterList.Items {
+		u := &storagev1.Cluster{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.ClusterSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementRunners(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	runnerList, err := managementClient.Loft().ManagementV1().Runners().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range runnerList.Items {
+		u := &storagev1.Runner{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.RunnerSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementClusterRoles(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	objs, err := managementClient.Loft().ManagementV1().ClusterRoleTemplates().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range objs.Items {
+		u := &storagev1.ClusterRoleTemplate{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.ClusterRoleTemplateSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementSpaceConstraints(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	objs, err := managementClient.Loft().ManagementV1().SpaceConstraints().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range objs.Items {
+		u := &storagev1.SpaceConstraint{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.SpaceConstraintSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementClusterAccess(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	objs, err := managementClient.Loft().ManagementV1().ClusterAccesses().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range objs.Items {
+		u := &storagev1.ClusterAccess{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.ClusterAccessSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementVirtualClusterTemplates(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	virtualClusterTemplates, err := managementClient.Loft().ManagementV1().VirtualClusterTemplates().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range virtualClusterTemplates.Items {
+		u := &storagev1.VirtualClusterTemplate{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.VirtualClusterTemplateSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementSpaceTemplates(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	spaceTemplates, err := managementClient.Loft().ManagementV1().SpaceTemplates().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range spaceTemplates.Items {
+		u := &storagev1.SpaceTemplate{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.SpaceTemplateSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementDevPodWorkspaceTemplates(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	devPodWorkspaceTemplates, err := managementClient.Loft().ManagementV1().DevPodWorkspaceTemplates().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range devPodWorkspaceTemplates.Items {
+		u := &storagev1.DevPodWorkspaceTemplate{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.DevPodWorkspaceTemplateSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementApps(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	apps, err := managementClient.Loft().ManagementV1().Apps().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range apps.Items {
+		u := &storagev1.App{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.AppSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementSharedSecrets(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	sharedSecretList, err := managementClient.Loft().ManagementV1().SharedSecrets("").List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range sharedSecretList.Items {
+		u := &storagev1.SharedSecret{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.SharedSecretSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementTeams(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	teamList, err := managementClient.Loft().ManagementV1().Teams().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range teamList.Items {
+		u := &storagev1.Team{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.TeamSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
+
+func managementUsers(ctx context.Context, managementClient kube.Interface, scheme *runtime.Scheme, listOptions metav1.ListOptions) ([]runtime.Object, error) {
+	userList, err := managementClient.Loft().ManagementV1().Users().List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	retList := []runtime.Object{}
+	for _, o := range userList.Items {
+		u := &storagev1.User{
+			ObjectMeta: o.ObjectMeta,
+			Spec:       o.Spec.UserSpec,
+		}
+		err := resetMetadata(scheme, u)
+		if err != nil {
+			return nil, err
+		}
+
+		retList = append(retList, u)
+	}
+
+	return retList, nil
+}
@@ -0,0 +1,50 @@
+package backup
+
+import (
+	"testing"
+
+	managementv1 "github.com/loft-sh/api/v4/pkg/apis/management/v1"
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProjectSecretToSecret(t *testing.T) {
+	projectSecret := &managementv1.ProjectSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db",
+			Namespace: "loft-p-test",
+			Labels:    map[string]string{"app": "db"},
+		},
+		Spec: managementv1.ProjectSecretSpec{
+			DisplayName: "Database",
+			Description: "Database credentials",
+			Owner:       &storagev1.UserOrTeam{User: "admin"},
+			Data:        map[string][]byte{"password": []byte("secret")},
+			Access: []storagev1.Access{
+				{Verbs: []string{"get"}, Users: []string{"*"}},
+			},
+		},
+	}
+
+	secret, err := projectSecretToSecret(projectSecret)
+	assert.NilError(t, err)
+	assert.Equal(t, secret.Name, "db")
+	assert.Equal(t, secret.Namespace, "loft-p-test")
+	assert.DeepEqual(t, secret.Data, projectSecret.Spec.Data)
+	assert.DeepEqual(t, secret.Labels, map[string]string{
+		"app":                         "db",
+		"loft.sh/project-secret":      "true",
+		"loft.sh/project-secret-name": "db",
+	})
+	assert.DeepEqual(t, secret.Annotations, map[string]string{
+		"loft.sh/project-secret-displayname": "Database",
+		"loft.sh/project-secret-description": "Database credentials",
+		"loft.sh/project-secret-owner":       `{"user":"admin"}`,
+		"loft.sh/project-secret-access":      `[{"verbs":["get"],"users":["*"]}]`,
+	})
+	assert.Assert(t, isProjectSecret(*secret))
+
+	// the project secret itself is not modified
+	assert.DeepEqual(t, projectSecret.Labels, map[string]string{"app": "db"})
+}
@@ -0,0 +1,163 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// projectResources are the resources that are backed up per project
+var projectResources = []string{"virtualclusterinstances", "devpodworkspaceinstances", "spaceinstances", "projectsecrets"}
+
+// templateResources maps the template resources to their kinds
+var templateResources = map[string]string{
+	"virtualclustertemplates":  "VirtualClusterTemplate",
+	"spacetemplates":           "SpaceTemplate",
+	"devpodworkspacetemplates": "DevPodWorkspaceTemplate",
+}
+
+// backupPlan holds the collectors of a single backup source
+type backupPlan struct {
+	// collectors back up the cluster scoped resources including the projects
+	collectors []collector
+
+	// projectCollectors back up the resources within the given projects
+	projectCollectors func(projects []string) []collector
+
+	// listProjects returns the names of all projects, independent of any filters
+	listProjects func(ctx context.Context) ([]string, error)
+
+	// getTemplates returns the templates of the given kind with the given names
+	getTemplates func(ctx context.Context, kind string, names []string) ([]runtime.Object, error)
+}
+
+// runPlan runs the collectors of the plan in three phases: cluster scoped resources, project
+// scoped resources and, if the backup is limited to projects, the templates referenced by the
+// backed up instances
+func runPlan(ctx context.Context, plan backupPlan, options Options) ([]runtime.Object, []error) {
+	objects, backupErrors := runCollectors(ctx, filterCollectors(plan.collectors, options), options)
+	if len(options.Projects) > 0 {
+		objects = filterProjects(objects, options.Projects)
+	}
+
+	// project scoped resources can only be collected once the projects are known
+	if contains(options.Skip, "projects") || !anyEnabled(options, projectResources) {
+		return objects, backupErrors
+	}
+
+	allProjects, err := plan.listProjects(ctx)
+	if err != nil {
+		return objects, append(backupErrors, errors.Wrap(err, "list projects"))
+	}
+	projects, err := selectProjects(allProjects, options.Projects)
+	if err != nil {
+		return objects, append(backupErrors, err)
+	} else if len(projects) == 0 {
+		return objects, backupErrors
+	}
+
+	projectObjects, projectErrors := runCollectors(ctx, filterCollectors(plan.projectCollectors(projects), options), options)
+	objects = append(objects, projectObjects...)
+	backupErrors = append(backupErrors, projectErrors...)
+	if len(options.Projects) == 0 {
+		return objects, backupErrors
+	}
+
+	templateCollectors := []collector{}
+	references := referencedTemplates(projectObjects)
+	for _, name := range []string{"virtualclustertemplates", "spacetemplates", "devpodworkspacetemplates"} {
+		kind := templateResources[name]
+		templateNames := references[kind]
+		if len(templateNames) == 0 || options.enabled(name) || contains(options.Skip, name) {
+			continue
+		}
+
+		templateCollectors = append(templateCollectors, collector{
+			name:     name,
+			resource: "referenced " + name,
+			collect: func(ctx context.Context) ([]runtime.Object, error) {
+				return plan.getTemplates(ctx, kind, templateNames)
+			},
+		})
+	}
+	templateObjects, templateErrors := runCollectors(ctx, templateCollectors, options)
+
+	return append(objects, templateObjects...), append(backupErrors, templateErrors...)
+}
+
+// selectProjects returns the given projects or all projects if none are given
+func selectProjects(allProjects []string, projects []string) ([]string, error) {
+	if len(projects) == 0 {
+		return allProjects, nil
+	}
+
+	for _, project := range projects {
+		if !contains(allProjects, project) {
+			return nil, fmt.Errorf("project %s not found", project)
+		}
+	}
+
+	return projects, nil
+}
+
+// filterProjects removes all projects from the objects that are not in the given names
+func filterProjects(objects []runtime.Object, names []string) []runtime.Object {
+	retObjects := []runtime.Object{}
+	for _, obj := range objects {
+		if project, ok := obj.(*storagev1.Project); ok && !contains(names, project.Name) {
+			continue
+		}
+
+		retObjects = append(retObjects, obj)
+	}
+
+	return retObjects
+}
+
+// referencedTemplates returns the sorted template names referenced by the given instances by kind
+func referencedTemplates(objects []runtime.Object) map[string][]string {
+	references := map[string]map[string]bool{}
+	add := func(kind string, templateRef *storagev1.TemplateRef) {
+		if templateRef == nil || templateRef.Name == "" {
+			return
+		} else if references[kind] == nil {
+			references[kind] = map[string]bool{}
+		}
+
+		references[kind][templateRef.Name] = true
+	}
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *storagev1.VirtualClusterInstance:
+			add("VirtualClusterTemplate", o.Spec.TemplateRef)
+		case *storagev1.SpaceInstance:
+			add("SpaceTemplate", o.Spec.TemplateRef)
+		case *storagev1.DevPodWorkspaceInstance:
+			add("DevPodWorkspaceTemplate", o.Spec.TemplateRef)
+		}
+	}
+
+	retReferences := map[string][]string{}
+	for kind, names := range references {
+		for name := range names {
+			retReferences[kind] = append(retReferences[kind], name)
+		}
+		sort.Strings(retReferences[kind])
+	}
+
+	return retReferences
+}
+
+func anyEnabled(options Options, names []string) bool {
+	for _, name := range names {
+		if options.enabled(name) {
+			return true
+		}
+	}
+
+	return false
+}
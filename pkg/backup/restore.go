@@ -0,0 +1,246 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientpkg "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConflictPolicy defines what happens if an object from the backup already exists
+type ConflictPolicy string
+
+const (
+	ConflictPolicySkip      ConflictPolicy = "skip"
+	ConflictPolicyOverwrite ConflictPolicy = "overwrite"
+	ConflictPolicyFail      ConflictPolicy = "fail"
+)
+
+// RestoreOptions holds the options for restoring a backup
+type RestoreOptions struct {
+	// DryRun sends all requests as server side dry run requests
+	DryRun bool
+
+	// ConflictPolicy defines how existing objects are handled
+	ConflictPolicy ConflictPolicy
+}
+
+// restoreStages defines the order in which kinds are restored. Objects
+// of kinds that are not listed here are restored last.
+var restoreStages = [][]string{
+	{"Secret"},
+	{"User", "Team", "AccessKey", "SharedSecret"},
+	{"ClusterRoleTemplate", "SpaceTemplate", "VirtualClusterTemplate", "DevPodWorkspaceTemplate", "App"},
+	{"Project"},
+	{"Cluster", "ClusterAccess", "SpaceConstraint", "Runner"},
+	{"ProjectSecret", "VirtualClusterInstance", "SpaceInstance", "DevPodWorkspaceInstance"},
+}
+
+var (
+	// projectNamespaceInterval is how often the project namespaces are checked
+	projectNamespaceInterval = time.Second
+
+	// projectNamespaceTimeout is how long to wait for the project namespaces,
+	// which are created asynchronously by loft after a project was restored
+	projectNamespaceTimeout = time.Minute * 2
+)
+
+// FromYAML decodes a backup created by ToYAML into typed objects
+func FromYAML(scheme *runtime.Scheme, data []byte) ([]runtime.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	objects := []runtime.Object{}
+	for {
+		document, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, errors.Wrap(err, "read backup document")
+		} else if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(document, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode backup object")
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// Restore applies the given objects in dependency order
+func Restore(ctx context.Context, client clientpkg.Client, objects []runtime.Object, options RestoreOptions, infoFn LogFn) []error {
+	restoreErrors := []error{}
+	projects := []string{}
+	waitedForProjects := false
+	for _, o := range sortForRestore(objects) {
+		// objects in the project namespaces can only be created after loft
+		// created the namespaces for the restored projects
+		if !waitedForProjects && restoreStage(o) >= kindStage("ProjectSecret") {
+			waitedForProjects = true
+			if !options.DryRun && len(projects) > 0 {
+				err := waitForProjectNamespaces(ctx, client, projects, infoFn)
+				if err != nil {
+					restoreErrors = append(restoreErrors, err)
+				}
+			}
+		}
+
+		obj, ok := o.(clientpkg.Object)
+		if !ok {
+			restoreErrors = append(restoreErrors, fmt.Errorf("unexpected object type %T", o))
+			continue
+		} else if obj.GetName() == "" {
+			// empty objects are written for secrets that could not be found during backup
+			continue
+		}
+
+		gvk, err := GVKFrom(client.Scheme(), obj)
+		if err != nil {
+			restoreErrors = append(restoreErrors, err)
+			continue
+		}
+
+		displayName := gvk.Kind + " " + clientpkg.ObjectKeyFromObject(obj).String()
+		err = restoreObject(ctx, client, obj, options, displayName, infoFn)
+		if err != nil {
+			restoreErrors = append(restoreErrors, errors.Wrapf(err, "restore %s", displayName))
+			if options.ConflictPolicy == ConflictPolicyFail && kerrors.IsAlreadyExists(err) {
+				return restoreErrors
+			}
+		} else if gvk.Kind == "Project" {
+			projects = append(projects, obj.GetName())
+		}
+	}
+
+	return restoreErrors
+}
+
+func waitForProjectNamespaces(ctx context.Context, client clientpkg.Client, projects []string, infoFn LogFn) error {
+	pending := map[string]bool{}
+	for _, project := range projects {
+		pending[projectutil.ProjectNamespace(project)] = true
+	}
+
+	infoFn(fmt.Sprintf("Waiting for the namespaces of %d project(s)...", len(projects)))
+	err := wait.PollUntilContextTimeout(ctx, projectNamespaceInterval, projectNamespaceTimeout, true, func(ctx context.Context) (bool, error) {
+		for namespace := range pending {
+			err := client.Get(ctx, clientpkg.ObjectKey{Name: namespace}, &corev1.Namespace{})
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+
+				return false, err
+			}
+
+			delete(pending, namespace)
+		}
+
+		return len(pending) == 0, nil
+	})
+	if err != nil {
+		namespaces := []string{}
+		for namespace := range pending {
+			namespaces = append(namespaces, namespace)
+		}
+		sort.Strings(namespaces)
+
+		return errors.Wrapf(err, "wait for project namespaces %s", strings.Join(namespaces, ", "))
+	}
+
+	return nil
+}
+
+func restoreObject(ctx context.Context, client clientpkg.Client, obj clientpkg.Object, options RestoreOptions, displayName string, infoFn LogFn) error {
+	createOptions := []clientpkg.CreateOption{}
+	updateOptions := []clientpkg.UpdateOption{}
+	if options.DryRun {
+		createOptions = append(createOptions, clientpkg.DryRunAll)
+		updateOptions = append(updateOptions, clientpkg.DryRunAll)
+	}
+
+	err := client.Create(ctx, obj.DeepCopyObject().(clientpkg.Object), createOptions...)
+	if err == nil {
+		infoFn(fmt.Sprintf("Restored %s", displayName))
+		return nil
+	} else if !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	switch options.ConflictPolicy {
+	case ConflictPolicySkip:
+		infoFn(fmt.Sprintf("Skipped %s, because it already exists", displayName))
+		return nil
+	case ConflictPolicyOverwrite:
+		existing := obj.DeepCopyObject().(clientpkg.Object)
+		err = client.Get(ctx, clientpkg.ObjectKeyFromObject(obj), existing)
+		if err != nil {
+			return errors.Wrap(err, "get existing object")
+		}
+
+		updated := obj.DeepCopyObject().(clientpkg.Object)
+		updated.SetResourceVersion(existing.GetResourceVersion())
+		err = client.Update(ctx, updated, updateOptions...)
+		if err != nil {
+			return errors.Wrap(err, "overwrite existing object")
+		}
+
+		infoFn(fmt.Sprintf("Overwrote %s", displayName))
+		return nil
+	default:
+		return err
+	}
+}
+
+func sortForRestore(objects []runtime.Object) []runtime.Object {
+	sorted := make([]runtime.Object, len(objects))
+	copy(sorted, objects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return restoreStage(sorted[i]) < restoreStage(sorted[j])
+	})
+
+	return sorted
+}
+
+func restoreStage(obj runtime.Object) int {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+
+	// project secrets live in the project namespace, so they can only be
+	// restored after the projects were created
+	if secret, ok := obj.(*corev1.Secret); ok && isProjectSecret(*secret) {
+		kind = "ProjectSecret"
+	}
+
+	return kindStage(kind)
+}
+
+func kindStage(kind string) int {
+	for stage, kinds := range restoreStages {
+		if contains(kinds, kind) {
+			return stage
+		}
+	}
+
+	return len(restoreStages)
+}
@@ -0,0 +1,159 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagev1 "github.com/loft-sh/api/v4/pkg/apis/storage/v1"
+	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientpkg "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestSortForRestore(t *testing.T) {
+	objects := []runtime.Object{
+		&storagev1.VirtualClusterInstance{TypeMeta: metav1.TypeMeta{Kind: "VirtualClusterInstance"}},
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "project-secret",
+				Labels: map[string]string{"loft.sh/project-secret": "true"},
+			},
+		},
+		&storagev1.Project{TypeMeta: metav1.TypeMeta{Kind: "Project"}},
+		&storagev1.User{TypeMeta: metav1.TypeMeta{Kind: "User"}},
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: "password"},
+		},
+	}
+
+	kinds := []string{}
+	for _, obj := range sortForRestore(objects) {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		if secret, ok := obj.(*corev1.Secret); ok {
+			kind += "/" + secret.Name
+		}
+
+		kinds = append(kinds, kind)
+	}
+
+	assert.DeepEqual(t, kinds, []string{"Secret/password", "User", "Project", "VirtualClusterInstance", "Secret/project-secret"})
+}
+
+func TestFromYAML(t *testing.T) {
+	secrets := []runtime.Object{
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "loft"},
+			Data:       map[string][]byte{"password": []byte("a")},
+		},
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "loft"},
+			Data:       map[string][]byte{"password": []byte("b")},
+		},
+	}
+
+	out, err := ToYAML(secrets)
+	assert.NilError(t, err)
+
+	objects, err := FromYAML(clientgoscheme.Scheme, out)
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 2)
+	assert.DeepEqual(t, objects[1], secrets[1])
+}
+
+func TestRestoreWaitsForProjectNamespaces(t *testing.T) {
+	projectutil.SetProjectNamespacePrefix("loft-p-")
+	projectNamespaceInterval = time.Millisecond
+	defer func() { projectNamespaceInterval = time.Second }()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NilError(t, storagev1.AddToScheme(scheme))
+
+	// the namespace of a project is only created after it was polled a few
+	// times and namespaced objects can't be created before it exists
+	namespaceGets := 0
+	client := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, client clientpkg.WithWatch, key clientpkg.ObjectKey, obj clientpkg.Object, opts ...clientpkg.GetOption) error {
+			if _, ok := obj.(*corev1.Namespace); ok && key.Name == "loft-p-test" {
+				namespaceGets++
+				if namespaceGets == 3 {
+					err := client.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: key.Name}})
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			return client.Get(ctx, key, obj, opts...)
+		},
+		Create: func(ctx context.Context, client clientpkg.WithWatch, obj clientpkg.Object, opts ...clientpkg.CreateOption) error {
+			if obj.GetNamespace() != "" {
+				err := client.Get(ctx, clientpkg.ObjectKey{Name: obj.GetNamespace()}, &corev1.Namespace{})
+				if err != nil {
+					return err
+				}
+			}
+
+			return client.Create(ctx, obj, opts...)
+		},
+	}).Build()
+
+	objects := []runtime.Object{
+		&storagev1.VirtualClusterInstance{
+			TypeMeta:   metav1.TypeMeta{Kind: "VirtualClusterInstance", APIVersion: storagev1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "vcluster", Namespace: "loft-p-test"},
+		},
+		&storagev1.Project{
+			TypeMeta:   metav1.TypeMeta{Kind: "Project", APIVersion: storagev1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		},
+	}
+
+	errs := Restore(context.Background(), client, objects, RestoreOptions{ConflictPolicy: ConflictPolicySkip}, func(string) {})
+	assert.Equal(t, len(errs), 0)
+	assert.Equal(t, namespaceGets, 3)
+
+	err := client.Get(context.Background(), clientpkg.ObjectKey{Name: "vcluster", Namespace: "loft-p-test"}, &storagev1.VirtualClusterInstance{})
+	assert.NilError(t, err)
+}
+
+func TestRestoreProjectNamespaceTimeout(t *testing.T) {
+	projectutil.SetProjectNamespacePrefix("loft-p-")
+	projectNamespaceInterval = time.Millisecond
+	projectNamespaceTimeout = time.Millisecond * 20
+	defer func() {
+		projectNamespaceInterval = time.Second
+		projectNamespaceTimeout = time.Minute * 2
+	}()
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NilError(t, storagev1.AddToScheme(scheme))
+
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	objects := []runtime.Object{
+		&storagev1.Project{
+			TypeMeta:   metav1.TypeMeta{Kind: "Project", APIVersion: storagev1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		},
+		&storagev1.SpaceInstance{
+			TypeMeta:   metav1.TypeMeta{Kind: "SpaceInstance", APIVersion: storagev1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "space", Namespace: "loft-p-test"},
+		},
+	}
+
+	errs := Restore(context.Background(), client, objects, RestoreOptions{ConflictPolicy: ConflictPolicySkip}, func(string) {})
+	assert.Equal(t, len(errs), 1)
+	assert.ErrorContains(t, errs[0], "wait for project namespaces loft-p-test")
+}
@@ -0,0 +1,333 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// S3Destination stores backups in an S3 compatible bucket
+type S3Destination struct {
+	// Endpoint is the url of the S3 api, defaults to https://s3.<region>.amazonaws.com
+	Endpoint string
+
+	// Region is the region of the bucket
+	Region string
+
+	// Bucket is the name of the bucket
+	Bucket string
+
+	// Prefix is prepended to all object names
+	Prefix string
+
+	// PathStyle addresses the bucket as part of the path instead of the host, which is
+	// needed for most S3 compatible implementations such as MinIO
+	PathStyle bool
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	HTTPClient *http.Client
+}
+
+func newS3DestinationFromURL(parsed *url.URL) (*S3Destination, error) {
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("s3 destination %s is missing a bucket", parsed.String())
+	}
+
+	query := parsed.Query()
+	destination := &S3Destination{
+		Endpoint:        query.Get("endpoint"),
+		Region:          query.Get("region"),
+		Bucket:          parsed.Host,
+		Prefix:          strings.TrimPrefix(parsed.Path, "/"),
+		PathStyle:       parseBool(query.Get("path-style")),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		HTTPClient:      http.DefaultClient,
+	}
+	if destination.Region == "" {
+		destination.Region = os.Getenv("AWS_REGION")
+	}
+	if destination.Region == "" {
+		destination.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if destination.Region == "" {
+		destination.Region = "us-east-1"
+	}
+	if destination.Prefix != "" && !strings.HasSuffix(destination.Prefix, "/") {
+		destination.Prefix += "/"
+	}
+	if parseBool(query.Get("insecure-skip-tls-verify")) {
+		destination.HTTPClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	}
+	if destination.AccessKeyID == "" || destination.SecretAccessKey == "" {
+		return nil, fmt.Errorf("please set the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables to use an s3 destination")
+	}
+
+	return destination, nil
+}
+
+func (s *S3Destination) Write(ctx context.Context, name string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, s.Prefix+name, nil, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func (s *S3Destination) List(ctx context.Context) ([]string, error) {
+	names := []string{}
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if s.Prefix != "" {
+			query.Set("prefix", s.Prefix)
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		resp, err := s.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &s3ListResult{}
+		err = xml.NewDecoder(resp.Body).Decode(result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "decode list objects response")
+		}
+
+		for _, content := range result.Contents {
+			name := strings.TrimPrefix(content.Key, s.Prefix)
+			if name != "" && !strings.Contains(name, "/") {
+				names = append(names, name)
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+func (s *S3Destination) Delete(ctx context.Context, name string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.Prefix+name, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func (s *S3Destination) String() string {
+	return "s3://" + s.Bucket + "/" + s.Prefix
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// do sends a signed request for the given object key to the bucket and returns an
+// error for non 2xx responses
+func (s *S3Destination) do(ctx context.Context, method, key string, query url.Values, body []byte) (*http.Response, error) {
+	requestURL, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		requestURL.RawQuery = encodeQuery(query)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	// send the path exactly as it is signed, otherwise keys with characters such
+	// as ':' are escaped differently on the wire and the signature doesn't match
+	req.URL.RawPath = encodePath(req.URL.Path)
+	if method == http.MethodPut {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	signV4(req, body, s.AccessKeyID, s.SecretAccessKey, s.SessionToken, s.Region, time.Now())
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+
+		out, _ := io.ReadAll(resp.Body)
+		s3Err := &s3Error{}
+		if xml.Unmarshal(out, s3Err) == nil && s3Err.Code != "" {
+			return nil, fmt.Errorf("s3 %s %s: %s (%s)", method, requestURL.Path, s3Err.Message, s3Err.Code)
+		}
+
+		return nil, fmt.Errorf("s3 %s %s: unexpected status code %d: %s", method, requestURL.Path, resp.StatusCode, string(out))
+	}
+
+	return resp, nil
+}
+
+func (s *S3Destination) objectURL(key string) (*url.URL, error) {
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + s.Region + ".amazonaws.com"
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse s3 endpoint")
+	}
+
+	if s.PathStyle {
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/" + s.Bucket + "/" + key
+	} else {
+		parsed.Host = s.Bucket + "." + parsed.Host
+		parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/" + key
+	}
+
+	return parsed, nil
+}
+
+// signV4 signs the request with AWS signature version 4. All headers
+// that are set on the request at this point are signed.
+func signV4(req *http.Request, body []byte, accessKeyID, secretAccessKey, sessionToken, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	payloadHash := checksum(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	headerNames := []string{}
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	canonicalHeaders := &strings.Builder{}
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		encodePath(req.URL.Path),
+		encodeQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		checksum([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKeyID, scope, signedHeaders, signature))
+}
+
+// encodePath encodes every segment of the path as required by signature version 4
+func encodePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// encodeQuery encodes the query sorted by key as required by signature version 4
+func encodeQuery(query url.Values) string {
+	keys := []string{}
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := []string{}
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+func uriEncode(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
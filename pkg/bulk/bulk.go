@@ -0,0 +1,124 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/log/survey"
+	flag "github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
+)
+
+// Flags select several space or virtual cluster instances of a project at once
+type Flags struct {
+	Selector string
+	All      bool
+}
+
+// AddFlags adds --selector and --all, kind is the plural of the instances
+func (f *Flags) AddFlags(flags *flag.FlagSet, kind string) {
+	flags.StringVarP(&f.Selector, "selector", "l", "", "If set, runs for all "+kind+" of the project matching the label selector, e.g. -l key1=value1,key2=value2")
+	flags.BoolVar(&f.All, "all", false, "If true, runs for all "+kind+" of the project")
+}
+
+// Enabled returns true if more than one name is given or the flags select the instances
+func (f *Flags) Enabled(names []string) bool {
+	return len(names) > 1 || f.Selector != "" || f.All
+}
+
+// Names returns the given names without duplicates or, if the flags are set, the names that list
+// returns for the label selector
+func (f *Flags) Names(project string, names []string, list func(labelSelector string) ([]string, error)) ([]string, error) {
+	if project == "" {
+		return nil, fmt.Errorf("please specify the project with --project")
+	} else if f.All && f.Selector != "" {
+		return nil, fmt.Errorf("--all and --selector cannot be used together")
+	} else if (f.All || f.Selector != "") && len(names) > 0 {
+		return nil, fmt.Errorf("names cannot be used together with --all or --selector")
+	}
+
+	if len(names) == 0 {
+		var err error
+		names, err = list(f.Selector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unique := []string{}
+	for _, name := range names {
+		if !slices.Contains(unique, name) {
+			unique = append(unique, name)
+		}
+	}
+
+	return unique, nil
+}
+
+// Confirm prints the names and asks if the action should run for all of them. If yes is true, the
+// names are printed without asking, so scripts can skip the question.
+func Confirm(action string, names []string, yes bool, log log.Logger) (bool, error) {
+	if len(names) == 0 {
+		return true, nil
+	}
+
+	log.Infof("This will %s %d instances: %s", action, len(names), strings.Join(names, ", "))
+	if yes {
+		return true, nil
+	}
+
+	answer, err := log.Question(&survey.QuestionOptions{
+		Question:     fmt.Sprintf("Do you want to %s these %d instances?", action, len(names)),
+		DefaultValue: "No",
+		Options:      []string{"Yes", "No"},
+	})
+	if err != nil {
+		return false, fmt.Errorf("ask for confirmation: %w. Please use --yes to %s without confirmation", err, action)
+	}
+
+	return answer == "Yes", nil
+}
+
+// Run calls op for every name with at most helper.MaxConcurrentRequests calls in parallel. Failed
+// calls don't stop the others, every failure is logged when it happens and a summary once all
+// are done. The returned error names the failed instances.
+func Run(ctx context.Context, action string, names []string, op func(ctx context.Context, name string) error, log log.Logger) error {
+	if len(names) == 0 {
+		log.Infof("Nothing to %s", action)
+		return nil
+	}
+
+	errs := make([]error, len(names))
+	group := errgroup.Group{}
+	group.SetLimit(helper.MaxConcurrentRequests)
+	for idx := range names {
+		idx := idx
+		group.Go(func() error {
+			errs[idx] = op(ctx, names[idx])
+			if errs[idx] != nil {
+				log.Errorf("Failed to %s %s: %v", action, names[idx], errs[idx])
+			}
+
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	failed := []string{}
+	for idx, err := range errs {
+		if err != nil {
+			failed = append(failed, names[idx])
+		}
+	}
+	if len(failed) > 0 {
+		log.Warnf("%s: %d succeeded, %d failed", action, len(names)-len(failed), len(failed))
+		return fmt.Errorf("failed to %s %s", action, strings.Join(failed, ", "))
+	}
+
+	log.Donef("%s: %d succeeded", action, len(names))
+	return nil
+}
@@ -0,0 +1,99 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
+	"github.com/loft-sh/log"
+	"gotest.tools/v3/assert"
+)
+
+func TestNames(t *testing.T) {
+	list := func(labelSelector string) ([]string, error) {
+		if labelSelector == "env=preview" {
+			return []string{"preview-1", "preview-2"}, nil
+		}
+
+		return []string{"preview-1", "preview-2", "prod"}, nil
+	}
+
+	names, err := (&Flags{}).Names("project", []string{"a", "b", "a"}, list)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"a", "b"})
+
+	names, err = (&Flags{Selector: "env=preview"}).Names("project", nil, list)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"preview-1", "preview-2"})
+
+	names, err = (&Flags{All: true}).Names("project", nil, list)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"preview-1", "preview-2", "prod"})
+
+	_, err = (&Flags{All: true}).Names("", nil, list)
+	assert.ErrorContains(t, err, "--project")
+
+	_, err = (&Flags{All: true, Selector: "env=preview"}).Names("project", nil, list)
+	assert.ErrorContains(t, err, "cannot be used together")
+
+	_, err = (&Flags{All: true}).Names("project", []string{"a"}, list)
+	assert.ErrorContains(t, err, "cannot be used together")
+}
+
+func TestRun(t *testing.T) {
+	defer func(limit int) { helper.MaxConcurrentRequests = limit }(helper.MaxConcurrentRequests)
+	helper.MaxConcurrentRequests = 3
+
+	names := []string{}
+	for i := 0; i < 20; i++ {
+		names = append(names, fmt.Sprintf("instance-%d", i))
+	}
+
+	var running, maxRunning int32
+	done := sync.Map{}
+	err := Run(context.Background(), "sleep", names, func(ctx context.Context, name string) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		done.Store(name, true)
+		if name == "instance-3" || name == "instance-12" {
+			return fmt.Errorf("timed out")
+		}
+
+		return nil
+	}, log.Discard)
+	assert.Error(t, err, "failed to sleep instance-3, instance-12")
+	assert.Assert(t, maxRunning <= 3)
+	for _, name := range names {
+		_, ok := done.Load(name)
+		assert.Assert(t, ok, name)
+	}
+
+	err = Run(context.Background(), "sleep", nil, nil, log.Discard)
+	assert.NilError(t, err)
+}
+
+func TestConfirm(t *testing.T) {
+	confirmed, err := Confirm("delete", []string{"a", "b"}, true, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, confirmed)
+
+	confirmed, err = Confirm("delete", nil, false, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, confirmed)
+
+	// without a terminal the question fails instead of deleting without confirmation
+	_, err = Confirm("delete", []string{"a", "b"}, false, log.Discard)
+	assert.ErrorContains(t, err, "--yes")
+}
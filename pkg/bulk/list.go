@@ -0,0 +1,49 @@
+package bulk
+
+import (
+	"context"
+
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/client/helper"
+)
+
+// SpaceInstanceNames returns a list function for Flags.Names that lists the space instances of the project
+func SpaceInstanceNames(ctx context.Context, baseClient client.Client, project string) func(labelSelector string) ([]string, error) {
+	return func(labelSelector string) ([]string, error) {
+		spaces, err := helper.ListSpaceInstances(ctx, baseClient, helper.InstanceListOptions{
+			Projects:      []string{project},
+			LabelSelector: labelSelector,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		names := []string{}
+		for _, space := range spaces {
+			names = append(names, space.SpaceInstance.Name)
+		}
+
+		return names, nil
+	}
+}
+
+// VirtualClusterInstanceNames returns a list function for Flags.Names that lists the virtual cluster
+// instances of the project
+func VirtualClusterInstanceNames(ctx context.Context, baseClient client.Client, project string) func(labelSelector string) ([]string, error) {
+	return func(labelSelector string) ([]string, error) {
+		virtualClusters, err := helper.ListVirtualClusterInstances(ctx, baseClient, helper.InstanceListOptions{
+			Projects:      []string{project},
+			LabelSelector: labelSelector,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		names := []string{}
+		for _, virtualCluster := range virtualClusters {
+			names = append(names, virtualCluster.VirtualCluster.Name)
+		}
+
+		return names, nil
+	}
+}
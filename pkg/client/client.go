@@ -28,12 +28,12 @@ import (
 	"k8s.io/utils/ptr"
 
 	"github.com/loft-sh/loftctl/v4/pkg/constants"
+	"github.com/loft-sh/loftctl/v4/pkg/httputil"
 	"github.com/loft-sh/loftctl/v4/pkg/kube"
 	"github.com/loft-sh/loftctl/v4/pkg/upgrade"
 	"github.com/loft-sh/log"
 	"github.com/mitchellh/go-homedir"
 	perrors "github.com/pkg/errors"
-	"github.com/skratchdot/open-golang/open"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
@@ -45,11 +45,13 @@ var CacheFolder = ".loft"
 var DefaultCacheConfig = "config.json"
 
 const (
-	VersionPath   = "%s/version"
-	LoginPath     = "%s/login?cli=true"
-	RedirectPath  = "%s/spaces"
-	AccessKeyPath = "%s/profile/access-keys"
-	RefreshToken  = time.Minute * 30
+	VersionPath     = "%s/version"
+	LoginPath       = "%s/login?cli=true"
+	DevicePath      = "%s/auth/device"
+	DeviceTokenPath = "%s/auth/device/token"
+	RedirectPath    = "%s/spaces"
+	AccessKeyPath   = "%s/profile/access-keys"
+	RefreshToken    = time.Minute * 30
 )
 
 func init() {
@@ -81,6 +83,7 @@ type Client interface {
 	VirtualClusterConfig(cluster, namespace, virtualCluster string) (*rest.Config, error)
 
 	Login(host string, insecure bool, log log.Logger) error
+	LoginWithOptions(ctx context.Context, host string, insecure bool, options LoginOptions, log log.Logger) error
 	LoginWithAccessKey(host, accessKey string, insecure bool) error
 	LoginRaw(host, accessKey string, insecure bool) error
 
@@ -137,6 +140,9 @@ type client struct {
 	configPath string
 	configOnce sync.Once
 
+	// currentProfile is the current profile of the config file
+	currentProfile string
+
 	self *managementv1.Self
 }
 
@@ -146,7 +152,8 @@ func (c *client) RefreshSelf(ctx context.Context) error {
 		return fmt.Errorf("create mangement client: %w", err)
 	}
 
-	c.self, err = managementClient.Loft().ManagementV1().Selves().Create(ctx, &managementv1.Self{}, metav1.CreateOptions{})
+	// creating a self has no side effects, so it's safe to retry
+	c.self, err = managementClient.Loft().ManagementV1().Selves().Create(httputil.WithIdempotent(ctx), &managementv1.Self{}, metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("get self: %w", err)
 	}
@@ -171,7 +178,7 @@ func (c *client) Logout(ctx context.Context) error {
 		return fmt.Errorf("create management client: %w", err)
 	}
 
-	self, err := managementClient.Loft().ManagementV1().Selves().Create(ctx, &managementv1.Self{}, metav1.CreateOptions{})
+	self, err := managementClient.Loft().ManagementV1().Selves().Create(httputil.WithIdempotent(ctx), &managementv1.Self{}, metav1.CreateOptions{})
 	if err != nil {
 		return fmt.Errorf("get self: %w", err)
 	}
@@ -190,24 +197,20 @@ func (c *client) initConfig() error {
 	var retErr error
 	c.configOnce.Do(func() {
 		// load the config or create new one if not found
-		content, err := os.ReadFile(c.configPath)
+		config, err := LoadConfig(c.configPath)
 		if err != nil {
-			if os.IsNotExist(err) {
-				c.config = NewConfig()
-				return
-			}
-
 			retErr = err
 			return
 		}
 
-		config := &Config{
-			VirtualClusterAccessPointCertificates: make(map[string]VirtualClusterCertificatesEntry),
-		}
-		err = json.Unmarshal(content, config)
-		if err != nil {
-			retErr = err
-			return
+		c.currentProfile = config.CurrentProfile
+		if profileOverride != "" && profileOverride != config.CurrentProfile {
+			retErr = ValidateProfileName(profileOverride)
+			if retErr != nil {
+				return
+			}
+
+			config.selectProfile(profileOverride)
 		}
 
 		c.config = config
@@ -332,24 +335,33 @@ func (c *client) Save() error {
 	if c.config == nil {
 		return perrors.New("no config to write")
 	}
-	if c.config.Kind == "" {
-		c.config.Kind = "Config"
+
+	// store the login in the selected profile
+	if c.config.CurrentProfile == "" {
+		c.config.CurrentProfile = DefaultProfile
 	}
-	if c.config.APIVersion == "" {
-		c.config.APIVersion = "storage.loft.sh/v1"
+	if c.config.Profiles == nil {
+		c.config.Profiles = map[string]*Profile{}
 	}
-
-	err := os.MkdirAll(filepath.Dir(c.configPath), 0o755)
-	if err != nil {
-		return err
+	c.config.Profiles[c.config.CurrentProfile] = c.config.profile()
+	if c.currentProfile == "" || c.currentProfile == c.config.CurrentProfile {
+		c.currentProfile = c.config.CurrentProfile
+		return SaveConfig(c.configPath, c.config)
 	}
 
-	out, err := json.Marshal(c.config)
+	// a profile other than the current one was selected via --profile, so
+	// keep the current profile as is
+	config := *c.config
+	config.selectProfile(c.currentProfile)
+	err := SaveConfig(c.configPath, &config)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(c.configPath, out, 0o660)
+	c.config.credentialBackend = config.credentialBackend
+	c.config.storedCredentials = config.storedCredentials
+	c.config.loadedProfiles = config.loadedProfiles
+	return nil
 }
 
 func (c *client) ManagementConfig() (*rest.Config, error) {
@@ -421,10 +433,6 @@ func (c *client) Config() *Config {
 	return c.config
 }
 
-type keyStruct struct {
-	Key string
-}
-
 func verifyHost(host string) error {
 	if !strings.HasPrefix(host, "https") {
 		return fmt.Errorf("cannot log into a non https loft instance '%s', please make sure you have TLS enabled", host)
@@ -459,43 +467,7 @@ func (c *client) Version() (*auth.Version, error) {
 }
 
 func (c *client) Login(host string, insecure bool, log log.Logger) error {
-	var (
-		loginUrl   = fmt.Sprintf(LoginPath, host)
-		key        keyStruct
-		keyChannel = make(chan keyStruct)
-	)
-
-	err := verifyHost(host)
-	if err != nil {
-		return err
-	}
-
-	server := startServer(fmt.Sprintf(RedirectPath, host), keyChannel, log)
-	err = open.Run(fmt.Sprintf(LoginPath, host))
-	if err != nil {
-		return fmt.Errorf("couldn't open the login page in a browser: %w. Please use the --access-key flag for the login command. You can generate an access key here: %s", err, fmt.Sprintf(AccessKeyPath, host))
-	} else {
-		log.Infof("If the browser does not open automatically, please navigate to %s", loginUrl)
-		msg := "If you have problems logging in, please navigate to %s/profile/access-keys, click on 'Create Access Key' and then login via '%s %s --access-key ACCESS_KEY"
-		if insecure {
-			msg += " --insecure"
-		}
-		msg += "'"
-		log.Infof(msg, host, product.LoginCmd(), host)
-		log.Infof("Logging into %s...", product.DisplayName())
-
-		key = <-keyChannel
-	}
-
-	go func() {
-		err = server.Shutdown(context.Background())
-		if err != nil {
-			log.Debugf("Error shutting down server: %v", err)
-		}
-	}()
-
-	close(keyChannel)
-	return c.LoginWithAccessKey(host, key.Key, insecure)
+	return c.LoginWithOptions(context.Background(), host, insecure, LoginOptions{CallbackPort: DefaultCallbackPort, ValidateState: true}, log)
 }
 
 func (c *client) LoginRaw(host, accessKey string, insecure bool) error {
@@ -524,7 +496,7 @@ func (c *client) LoginWithAccessKey(host, accessKey string, insecure bool) error
 	if c.config.AccessKey != "" {
 		managementClient, err := c.Management()
 		if err == nil {
-			self, err := managementClient.Loft().ManagementV1().Selves().Create(context.TODO(), &managementv1.Self{}, metav1.CreateOptions{})
+			self, err := managementClient.Loft().ManagementV1().Selves().Create(httputil.WithIdempotent(context.TODO()), &managementv1.Self{}, metav1.CreateOptions{})
 			if err == nil && self.Status.AccessKey != "" && self.Status.AccessKeyType == storagev1.AccessKeyTypeLogin {
 				_ = managementClient.Loft().ManagementV1().OwnedAccessKeys().Delete(context.TODO(), self.Status.AccessKey, metav1.DeleteOptions{})
 			}
@@ -550,7 +522,7 @@ func (c *client) LoginWithAccessKey(host, accessKey string, insecure bool) error
 	}
 
 	// try to get self
-	_, err = managementClient.Loft().ManagementV1().Selves().Create(context.TODO(), &managementv1.Self{}, metav1.CreateOptions{})
+	_, err = managementClient.Loft().ManagementV1().Selves().Create(httputil.WithIdempotent(context.TODO()), &managementv1.Self{}, metav1.CreateOptions{})
 	if err != nil {
 		var urlError *url.Error
 		if errors.As(err, &urlError) {
@@ -607,15 +579,46 @@ func (c *client) restConfig(hostSuffix string) (*rest.Config, error) {
 	}
 
 	// build a rest config
-	config, err := GetRestConfig(c.config.Host+hostSuffix, c.config.AccessKey, c.config.Insecure)
+	config, err := GetRestConfigWithTLS(c.config.Host+hostSuffix, c.config.AccessKey, c.config.TLSOptions())
 	if err != nil {
 		return nil, err
 	}
 
+	// retry transient failures, e.g. while loft is restarting
+	retryOptions := c.retryOptions()
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return httputil.NewRetryTransport(rt, retryOptions)
+	})
+
 	return config, err
 }
 
+// retryOptions returns the retry options from the config, LOFT_MAX_RETRIES takes precedence
+func (c *client) retryOptions() httputil.RetryOptions {
+	options := httputil.DefaultRetryOptions()
+	options.Logf = log.GetInstance().Debugf
+	if c.config.MaxRetries != nil {
+		options.MaxRetries = *c.config.MaxRetries
+	}
+
+	if value := os.Getenv(constants.LoftMaxRetriesEnv); value != "" {
+		maxRetries, err := strconv.Atoi(value)
+		if err != nil {
+			log.GetInstance().Debugf("ignoring invalid %s %q: %v", constants.LoftMaxRetriesEnv, value, err)
+		} else {
+			options.MaxRetries = maxRetries
+		}
+	}
+
+	return options
+}
+
 func GetKubeConfig(host, token, namespace string, insecure bool) clientcmd.ClientConfig {
+	return GetKubeConfigWithTLS(host, token, namespace, TLSOptions{Insecure: insecure})
+}
+
+// GetKubeConfigWithTLS returns a client config for the given host that verifies it with the tls options
+func GetKubeConfigWithTLS(host, token, namespace string, tlsOptions TLSOptions) clientcmd.ClientConfig {
 	contextName := "local"
 	kubeConfig := clientcmdapi.NewConfig()
 	kubeConfig.Contexts = map[string]*clientcmdapi.Context{
@@ -627,8 +630,10 @@ func GetKubeConfig(host, token, namespace string, insecure bool) clientcmd.Clien
 	}
 	kubeConfig.Clusters = map[string]*clientcmdapi.Cluster{
 		contextName: {
-			Server:                host,
-			InsecureSkipTLSVerify: insecure,
+			Server:                   host,
+			InsecureSkipTLSVerify:    tlsOptions.Insecure && !tlsOptions.HasCertificateAuthority(),
+			CertificateAuthority:     tlsOptions.CertificateAuthority,
+			CertificateAuthorityData: tlsOptions.CertificateAuthorityData,
 		},
 	}
 	kubeConfig.AuthInfos = map[string]*clientcmdapi.AuthInfo{
@@ -641,36 +646,19 @@ func GetKubeConfig(host, token, namespace string, insecure bool) clientcmd.Clien
 }
 
 func GetRestConfig(host, token string, insecure bool) (*rest.Config, error) {
-	config, err := GetKubeConfig(host, token, "", insecure).ClientConfig()
+	return GetRestConfigWithTLS(host, token, TLSOptions{Insecure: insecure})
+}
+
+// GetRestConfigWithTLS returns a rest config for the given host that verifies it with the tls options
+func GetRestConfigWithTLS(host, token string, tlsOptions TLSOptions) (*rest.Config, error) {
+	config, err := GetKubeConfigWithTLS(host, token, "", tlsOptions).ClientConfig()
 	if err != nil {
 		return nil, err
 	}
 	config.UserAgent = constants.LoftctlUserAgentPrefix + upgrade.GetVersion()
+	if tracer != nil {
+		config.Wrap(tracer.Wrap)
+	}
 
 	return config, nil
 }
-
-func startServer(redirectURI string, keyChannel chan keyStruct, log log.Logger) *http.Server {
-	srv := &http.Server{Addr: ":25843"}
-
-	http.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
-		keys, ok := r.URL.Query()["key"]
-		if !ok || len(keys[0]) == 0 {
-			log.Warn("Login: the key used to login is not valid")
-			return
-		}
-
-		keyChannel <- keyStruct{
-			Key: keys[0],
-		}
-		http.Redirect(w, r, redirectURI, http.StatusSeeOther)
-	})
-
-	go func() {
-		// cannot panic, because this probably is an intentional close
-		_ = srv.ListenAndServe()
-	}()
-
-	// returning reference so caller can call Shutdown()
-	return srv
-}
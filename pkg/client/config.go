@@ -10,6 +10,25 @@ import (
 type Config struct {
 	metav1.TypeMeta `json:",inline"`
 
+	// CurrentProfile is the name of the profile that is used by default
+	// +optional
+	CurrentProfile string `json:"currentProfile,omitempty"`
+
+	// Profiles holds the logins of all profiles by name. The top level login
+	// fields always mirror the current profile.
+	// +optional
+	Profiles map[string]*Profile `json:"profiles,omitempty"`
+
+	// CredentialStore is the backend the secrets of the config are stored in.
+	// Can be either plaintext, keyring or file.
+	// +optional
+	CredentialStore string `json:"credentialStore,omitempty"`
+
+	// MaxRetries is how often failed management api requests are retried.
+	// Defaults to 5, 0 disables retries.
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
 	// host is the http endpoint of how to access loft
 	// +optional
 	Host string `json:"host,omitempty"`
@@ -22,6 +41,14 @@ type Config struct {
 	// +optional
 	Insecure bool `json:"insecure,omitempty"`
 
+	// CertificateAuthority is the path to a PEM encoded certificate authority bundle to verify the loft host with
+	// +optional
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+
+	// CertificateAuthorityData is a PEM encoded certificate authority bundle to verify the loft host with
+	// +optional
+	CertificateAuthorityData []byte `json:"certificateAuthorityData,omitempty"`
+
 	// access key is the access key for the given loft host
 	// +optional
 	AccessKey string `json:"accesskey,omitempty"`
@@ -43,6 +70,13 @@ type Config struct {
 	// map of cached certificates for "access point" mode virtual clusters
 	// +optional
 	VirtualClusterAccessPointCertificates map[string]VirtualClusterCertificatesEntry
+
+	// credentialBackend is the credential store the config was loaded from
+	credentialBackend string
+	// storedCredentials holds the secrets that are currently in the credential store
+	storedCredentials map[string]string
+	// loadedProfiles holds the names of the profiles that were on disk when the config was read
+	loadedProfiles map[string]bool
 }
 
 type VirtualClusterCertificatesEntry struct {
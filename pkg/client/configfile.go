@@ -0,0 +1,160 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LockTimeout is how long to wait for another process to release the config
+var LockTimeout = time.Second * 10
+
+// lockConfig acquires an exclusive lock on the config at the given path across
+// processes. The lock is held on a separate file, because the config itself is
+// replaced on every write.
+func lockConfig(path string) (func(), error) {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o660)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(LockTimeout)
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("lock config: %w", err)
+		} else if locked {
+			break
+		} else if time.Now().After(deadline) {
+			_ = file.Close()
+			return nil, fmt.Errorf("timed out waiting for the lock on %s, please remove %s.lock if no other process is running", path, path)
+		}
+
+		time.Sleep(time.Millisecond * 50)
+	}
+
+	return func() {
+		_ = unlockFile(file)
+		_ = file.Close()
+	}, nil
+}
+
+// writeFileAtomic replaces the file at the given path, so readers never see a partially written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmpFile.Name(), perm)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// mergeProfiles adds the profiles other processes created since the config was read, so
+// concurrent logins into different profiles don't drop each other. Profiles that were read
+// with the config, but are missing now, were deleted and are not added again.
+func mergeProfiles(path string, config, disk *Config) error {
+	added := &Config{Profiles: map[string]*Profile{}}
+	for name, profile := range disk.Profiles {
+		if _, ok := config.Profiles[name]; ok || config.loadedProfiles[name] {
+			continue
+		}
+
+		copied := *profile
+		added.Profiles[name] = &copied
+	}
+	if len(added.Profiles) == 0 {
+		return nil
+	}
+
+	err := walkSecrets(added, resolveCredentials(path, disk.CredentialStore))
+	if err != nil {
+		return err
+	}
+
+	if config.Profiles == nil {
+		config.Profiles = map[string]*Profile{}
+	}
+	for name, profile := range added.Profiles {
+		config.Profiles[name] = profile
+	}
+	return nil
+}
+
+// profileSet returns the names of all profiles of the config
+func profileSet(config *Config) map[string]bool {
+	names := make(map[string]bool, len(config.Profiles))
+	for name := range config.Profiles {
+		names[name] = true
+	}
+
+	return names
+}
+
+// mergeCertificates adds the cached certificates of the config on disk to the config,
+// so concurrent token requests for different virtual clusters don't drop each others
+// certificates. The most recently requested certificate wins. The config on disk is
+// read without resolving its credentials, so only the certificates taken from it are
+// looked up in its credential store.
+func mergeCertificates(path string, config, disk *Config) error {
+	resolve := resolveCredentials(path, disk.CredentialStore)
+	for name, profile := range config.Profiles {
+		diskProfile, ok := disk.Profiles[name]
+		if !ok || diskProfile.Host != profile.Host || len(diskProfile.VirtualClusterAccessPointCertificates) == 0 {
+			continue
+		}
+
+		certificates := make(map[string]VirtualClusterCertificatesEntry, len(profile.VirtualClusterAccessPointCertificates))
+		for contextName, entry := range profile.VirtualClusterAccessPointCertificates {
+			certificates[contextName] = entry
+		}
+		for contextName, diskEntry := range diskProfile.VirtualClusterAccessPointCertificates {
+			entry, ok := certificates[contextName]
+			if ok && !diskEntry.LastRequested.After(entry.LastRequested.Time) {
+				continue
+			}
+
+			var err error
+			prefix := name + "/certificates/" + contextName + "/"
+			diskEntry.CertificateData, err = resolve(prefix+"certificate", diskEntry.CertificateData)
+			if err != nil {
+				return err
+			}
+			diskEntry.KeyData, err = resolve(prefix+"key", diskEntry.KeyData)
+			if err != nil {
+				return err
+			}
+
+			certificates[contextName] = diskEntry
+		}
+		profile.VirtualClusterAccessPointCertificates = certificates
+	}
+
+	if profile, ok := config.Profiles[config.CurrentProfile]; ok {
+		config.applyProfile(profile)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/loftctl/v4/pkg/credentials"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSaveConfigConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := NewConfig()
+	config.Host = "https://my-loft.example.com"
+	config.AccessKey = "my-access-key"
+	config.CurrentProfile = DefaultProfile
+	config.Profiles = map[string]*Profile{DefaultProfile: config.profile()}
+	assert.NilError(t, SaveConfig(path, config))
+
+	// every process caches the certificate of another virtual cluster
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		waitGroup.Add(1)
+		go func(i int) {
+			defer waitGroup.Done()
+
+			config, err := LoadConfig(path)
+			assert.Check(t, err)
+			config.VirtualClusterAccessPointCertificates[fmt.Sprintf("vcluster-%d", i)] = VirtualClusterCertificatesEntry{
+				CertificateData: "cert",
+				KeyData:         "key",
+				LastRequested:   metav1.Now(),
+			}
+			config.Profiles[DefaultProfile] = config.profile()
+			assert.Check(t, SaveConfig(path, config))
+		}(i)
+	}
+	waitGroup.Wait()
+
+	config, err := LoadConfig(path)
+	assert.NilError(t, err)
+	assert.Equal(t, config.AccessKey, "my-access-key")
+	assert.Equal(t, len(config.VirtualClusterAccessPointCertificates), 10)
+}
+
+func TestSaveProfilesConcurrently(t *testing.T) {
+	t.Setenv(credentials.PassphraseEnv, "my-passphrase")
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := NewConfig()
+	config.CredentialStore = credentials.BackendFile
+	config.Host = "https://my-loft.example.com"
+	config.AccessKey = "my-access-key"
+	config.CurrentProfile = DefaultProfile
+	config.Profiles = map[string]*Profile{DefaultProfile: config.profile()}
+	assert.NilError(t, SaveConfig(path, config))
+
+	// both processes login into another profile
+	first, err := LoadConfig(path)
+	assert.NilError(t, err)
+	second, err := LoadConfig(path)
+	assert.NilError(t, err)
+	waitGroup := sync.WaitGroup{}
+	for name, c := range map[string]*Config{"first": first, "second": second} {
+		waitGroup.Add(1)
+		go func(name string, c *Config) {
+			defer waitGroup.Done()
+
+			c.Profiles[name] = &Profile{Host: "https://" + name + ".example.com", AccessKey: "key-" + name}
+			assert.Check(t, SaveConfig(path, c))
+		}(name, c)
+	}
+	waitGroup.Wait()
+
+	loaded, err := LoadConfig(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, loaded.ProfileNames(), []string{"default", "first", "second"})
+	assert.Equal(t, loaded.AccessKey, "my-access-key")
+	for _, name := range []string{"first", "second"} {
+		assert.Equal(t, loaded.Profiles[name].AccessKey, "key-"+name)
+	}
+
+	// deleted profiles are not merged back from disk
+	assert.NilError(t, loaded.DeleteProfile("second"))
+	assert.NilError(t, SaveConfig(path, loaded))
+	loaded, err = LoadConfig(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, loaded.ProfileNames(), []string{"default", "first"})
+}
+
+func TestMergeCertificates(t *testing.T) {
+	now := time.Now()
+	config := &Config{
+		CurrentProfile: DefaultProfile,
+		Profiles: map[string]*Profile{
+			DefaultProfile: {
+				Host: "https://my-loft.example.com",
+				VirtualClusterAccessPointCertificates: map[string]VirtualClusterCertificatesEntry{
+					"a": {CertificateData: "new", LastRequested: metav1.NewTime(now)},
+					"b": {CertificateData: "old", LastRequested: metav1.NewTime(now.Add(-time.Hour))},
+				},
+			},
+			"relogin": {Host: "https://new.example.com"},
+		},
+	}
+	disk := &Config{
+		Profiles: map[string]*Profile{
+			DefaultProfile: {
+				Host: "https://my-loft.example.com",
+				VirtualClusterAccessPointCertificates: map[string]VirtualClusterCertificatesEntry{
+					"a": {CertificateData: "old", LastRequested: metav1.NewTime(now.Add(-time.Hour))},
+					"b": {CertificateData: "new", LastRequested: metav1.NewTime(now)},
+					"c": {CertificateData: "new", LastRequested: metav1.NewTime(now)},
+				},
+			},
+			"relogin": {
+				Host: "https://old.example.com",
+				VirtualClusterAccessPointCertificates: map[string]VirtualClusterCertificatesEntry{
+					"a": {CertificateData: "old"},
+				},
+			},
+		},
+	}
+
+	assert.NilError(t, mergeCertificates(filepath.Join(t.TempDir(), "config.json"), config, disk))
+	for _, contextName := range []string{"a", "b", "c"} {
+		assert.Equal(t, config.VirtualClusterAccessPointCertificates[contextName].CertificateData, "new")
+	}
+	assert.Equal(t, len(config.Profiles["relogin"].VirtualClusterAccessPointCertificates), 0)
+}
+
+func TestMergeCertificatesFromCredentialStore(t *testing.T) {
+	t.Setenv(credentials.PassphraseEnv, "my-passphrase")
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := NewConfig()
+	config.CredentialStore = credentials.BackendFile
+	config.Host = "https://my-loft.example.com"
+	config.AccessKey = "my-access-key"
+	config.CurrentProfile = DefaultProfile
+	config.Profiles = map[string]*Profile{DefaultProfile: config.profile()}
+	assert.NilError(t, SaveConfig(path, config))
+
+	first, err := LoadConfig(path)
+	assert.NilError(t, err)
+	second, err := LoadConfig(path)
+	assert.NilError(t, err)
+
+	// the certificate of the second process is only referenced in the config on disk
+	for contextName, c := range map[string]*Config{"b": second, "a": first} {
+		c.VirtualClusterAccessPointCertificates[contextName] = VirtualClusterCertificatesEntry{
+			CertificateData: "cert-" + contextName,
+			KeyData:         "key-" + contextName,
+			LastRequested:   metav1.Now(),
+		}
+		c.Profiles[DefaultProfile] = c.profile()
+	}
+	assert.NilError(t, SaveConfig(path, second))
+	assert.NilError(t, SaveConfig(path, first))
+	assert.Equal(t, first.VirtualClusterAccessPointCertificates["b"].KeyData, "key-b")
+
+	loaded, err := LoadConfig(path)
+	assert.NilError(t, err)
+	assert.Equal(t, loaded.AccessKey, "my-access-key")
+	for _, contextName := range []string{"a", "b"} {
+		assert.Equal(t, loaded.VirtualClusterAccessPointCertificates[contextName].CertificateData, "cert-"+contextName)
+		assert.Equal(t, loaded.VirtualClusterAccessPointCertificates[contextName].KeyData, "key-"+contextName)
+	}
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/loft-sh/loftctl/v4/pkg/credentials"
+)
+
+// credentialRefPrefix marks config values that only reference a secret in the credential store
+const credentialRefPrefix = "credential:"
+
+// loadCredentials replaces all credential references of the config with the
+// secrets from the credential store
+func loadCredentials(path string, config *Config) error {
+	config.credentialBackend = config.CredentialStore
+	config.storedCredentials = map[string]string{}
+
+	resolve := resolveCredentials(path, config.CredentialStore)
+	err := walkSecrets(config, func(key, value string) (string, error) {
+		secret, err := resolve(key, value)
+		if err != nil {
+			return "", err
+		} else if strings.HasPrefix(value, credentialRefPrefix) {
+			config.storedCredentials[key] = secret
+		}
+
+		return secret, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if profile, ok := config.Profiles[config.CurrentProfile]; ok {
+		config.applyProfile(profile)
+	}
+	return nil
+}
+
+// resolveCredentials returns a function that replaces a credential reference with the secret
+// from the credential store of the given backend. The store is only opened once a value
+// references it, other values are returned unchanged.
+func resolveCredentials(path, backend string) func(key, value string) (string, error) {
+	var store credentials.Store
+	return func(key, value string) (string, error) {
+		if !strings.HasPrefix(value, credentialRefPrefix) {
+			return value, nil
+		}
+
+		var err error
+		if store == nil {
+			store, err = credentials.NewStore(backend, path)
+			if err != nil {
+				return "", err
+			} else if store == nil {
+				return "", fmt.Errorf("config references credential %s, but has no credential store configured", key)
+			}
+		}
+
+		// a missing credential means the user needs to login again
+		secret, err := store.Get(strings.TrimPrefix(value, credentialRefPrefix))
+		if err != nil && !errors.Is(err, credentials.ErrNotFound) {
+			return "", err
+		}
+
+		return secret, nil
+	}
+}
+
+// storeCredentials writes all secrets of the config into the credential store and
+// returns a copy of the config that only holds references to them
+func storeCredentials(path string, config *Config) (*Config, map[string]string, error) {
+	store, err := credentials.NewStore(config.CredentialStore, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := config
+	stored := map[string]string{}
+	switched := config.credentialBackend != config.CredentialStore
+	if store != nil {
+		out = config.withCopiedProfiles()
+		err = walkSecrets(out, func(key, value string) (string, error) {
+			if previous, ok := config.storedCredentials[key]; switched || !ok || previous != value {
+				err := store.Set(key, value)
+				if err != nil {
+					return "", err
+				}
+			}
+
+			stored[key] = value
+			return credentialRefPrefix + key, nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		profile, ok := out.Profiles[out.CurrentProfile]
+		if !ok {
+			profile = &Profile{}
+		}
+		out.applyProfile(profile)
+	}
+
+	// remove credentials that are not referenced anymore, because they were
+	// removed from the config or the credential store was switched
+	if len(config.storedCredentials) > 0 {
+		previousStore := store
+		if switched {
+			previousStore, err = credentials.NewStore(config.credentialBackend, path)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		for key := range config.storedCredentials {
+			if _, ok := stored[key]; previousStore != nil && (switched || !ok) {
+				err = previousStore.Delete(key)
+				if err != nil && !errors.Is(err, credentials.ErrNotFound) {
+					return nil, nil, fmt.Errorf("delete credential %s: %w", key, err)
+				}
+			}
+		}
+	}
+
+	return out, stored, nil
+}
+
+// walkSecrets replaces every secret of the config profiles with the value returned by fn
+func walkSecrets(config *Config, fn func(key, value string) (string, error)) error {
+	var err error
+	for _, name := range config.ProfileNames() {
+		profile := config.Profiles[name]
+		fields := map[string]*string{
+			"accesskey":                  &profile.AccessKey,
+			"virtualClusterAccessKey":    &profile.VirtualClusterAccessKey,
+			"directClusterEndpointToken": &profile.DirectClusterEndpointToken,
+		}
+		for field, value := range fields {
+			if *value == "" {
+				continue
+			}
+
+			*value, err = fn(name+"/"+field, *value)
+			if err != nil {
+				return err
+			}
+		}
+
+		certificates := make(map[string]VirtualClusterCertificatesEntry, len(profile.VirtualClusterAccessPointCertificates))
+		for contextName, entry := range profile.VirtualClusterAccessPointCertificates {
+			prefix := name + "/certificates/" + contextName + "/"
+			if entry.CertificateData != "" {
+				entry.CertificateData, err = fn(prefix+"certificate", entry.CertificateData)
+				if err != nil {
+					return err
+				}
+			}
+			if entry.KeyData != "" {
+				entry.KeyData, err = fn(prefix+"key", entry.KeyData)
+				if err != nil {
+					return err
+				}
+			}
+
+			certificates[contextName] = entry
+		}
+		profile.VirtualClusterAccessPointCertificates = certificates
+	}
+
+	return nil
+}
+
+func (c *Config) withCopiedProfiles() *Config {
+	out := *c
+	out.Profiles = make(map[string]*Profile, len(c.Profiles))
+	for name, profile := range c.Profiles {
+		copied := *profile
+		out.Profiles[name] = &copied
+	}
+
+	return &out
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/loftctl/v4/pkg/credentials"
+	"gotest.tools/v3/assert"
+)
+
+func TestCredentialStore(t *testing.T) {
+	t.Setenv(credentials.PassphraseEnv, "my-passphrase")
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	config := NewConfig()
+	config.CredentialStore = credentials.BackendFile
+	config.CurrentProfile = DefaultProfile
+	config.Profiles = map[string]*Profile{
+		DefaultProfile: {
+			Host:      "https://my-loft.example.com",
+			AccessKey: "my-access-key",
+			VirtualClusterAccessPointCertificates: map[string]VirtualClusterCertificatesEntry{
+				"loft-vcluster_test_default": {CertificateData: "cert", KeyData: "key"},
+			},
+		},
+		"staging": {Host: "https://staging.example.com", AccessKey: "staging-access-key"},
+	}
+	config.applyProfile(config.Profiles[DefaultProfile])
+	assert.NilError(t, SaveConfig(path, config))
+
+	// the config only holds references
+	out, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(out), "access-key"))
+	assert.Assert(t, !strings.Contains(string(out), `"key"`))
+
+	loaded, err := LoadConfig(path)
+	assert.NilError(t, err)
+	assert.Equal(t, loaded.AccessKey, "my-access-key")
+	assert.Equal(t, loaded.VirtualClusterAccessPointCertificates["loft-vcluster_test_default"].KeyData, "key")
+	assert.Equal(t, loaded.Profiles["staging"].AccessKey, "staging-access-key")
+
+	// credentials of deleted profiles are removed from the store
+	assert.NilError(t, loaded.DeleteProfile("staging"))
+	assert.NilError(t, SaveConfig(path, loaded))
+	_, err = credentials.NewFileStore(credentials.FilePath(path), "my-passphrase").Get("staging/accesskey")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+
+	// switching back to plaintext moves the secrets into the config
+	loaded.CredentialStore = credentials.BackendPlaintext
+	assert.NilError(t, SaveConfig(path, loaded))
+	out, err = os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(out), "my-access-key"))
+	_, err = credentials.NewFileStore(credentials.FilePath(path), "my-passphrase").Get(DefaultProfile + "/accesskey")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
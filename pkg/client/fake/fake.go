@@ -0,0 +1,307 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	agentfake "github.com/loft-sh/agentapi/v4/pkg/client/loft/clientset_generated/clientset/fake"
+	managementv1 "github.com/loft-sh/api/v4/pkg/apis/management/v1"
+	"github.com/loft-sh/api/v4/pkg/auth"
+	loftfake "github.com/loft-sh/api/v4/pkg/client/clientset_generated/clientset/fake"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/kube"
+	"github.com/loft-sh/loftctl/v4/pkg/kubeconfig"
+	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
+	"github.com/loft-sh/log"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// Host is the host the fake client pretends to be logged into
+const Host = "https://loft.fake"
+
+var _ client.Client = &Client{}
+
+// Client is a client.Client backed by fake clientsets. It never talks to a loft instance,
+// so tools built on top of loftctl can be tested without one.
+type Client struct {
+	// Kube, Loft and Agent are the fake clientsets of the management api
+	Kube  *kubefake.Clientset
+	Loft  *loftfake.Clientset
+	Agent *agentfake.Clientset
+
+	// CurrentSelf is returned by Self and by creating a self via the management api
+	CurrentSelf *managementv1.Self
+
+	// CurrentVersion is returned by Version
+	CurrentVersion *auth.Version
+
+	// ClientConfig is returned by Config and updated by the login and logout methods
+	ClientConfig *client.Config
+
+	// KubeConfigs maps <namespace>/<name> of virtual cluster instances to the kube config that is
+	// returned by their kubeconfig subresource
+	KubeConfigs map[string]string
+
+	// Authorize decides self subject access reviews. If nil, everything is allowed.
+	Authorize func(attributes *authorizationv1.ResourceAttributes) bool
+
+	// Saves counts how often Save was called
+	Saves int
+
+	m         sync.Mutex
+	instances map[string]kube.Interface
+}
+
+// NewClient creates a fake client that is logged in as the given self. The given objects
+// are seeded into the management api.
+func NewClient(self *managementv1.Self, objects ...runtime.Object) *Client {
+	if self == nil {
+		self = &managementv1.Self{}
+	}
+
+	config := client.NewConfig()
+	config.Host = Host
+	config.AccessKey = "fake-access-key"
+
+	c := &Client{
+		CurrentSelf:    self,
+		CurrentVersion: &auth.Version{},
+		ClientConfig:   config,
+		KubeConfigs:    map[string]string{},
+		instances:      map[string]kube.Interface{},
+	}
+
+	c.Kube, c.Loft, c.Agent = newClientsets(objects...)
+	c.Loft.PrependReactor("create", "selves", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, c.Self(), nil
+	})
+	c.Loft.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*managementv1.SelfSubjectAccessReview).DeepCopy()
+		review.Status.Allowed = c.Authorize == nil || c.Authorize(review.Spec.ResourceAttributes)
+		review.Status.Denied = !review.Status.Allowed
+		return true, review, nil
+	})
+	c.Loft.PrependReactor("create", "virtualclusterinstances", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		createAction, ok := action.(clienttesting.CreateActionImpl)
+		if !ok || createAction.GetSubresource() != "kubeconfig" {
+			return false, nil, nil
+		}
+
+		name := createAction.Name
+		kubeConfig, ok := c.KubeConfigs[action.GetNamespace()+"/"+name]
+		if !ok {
+			return true, nil, fmt.Errorf("no kube config for virtual cluster instance %s/%s", action.GetNamespace(), name)
+		}
+
+		response := createAction.GetObject().(*managementv1.VirtualClusterInstanceKubeConfig).DeepCopy()
+		response.Status.KubeConfig = kubeConfig
+		return true, response, nil
+	})
+
+	return c
+}
+
+// NewKube creates a kube.Interface backed by fake clientsets and seeds the given objects
+func NewKube(objects ...runtime.Object) kube.Interface {
+	kubeClient, loftClient, agentClient := newClientsets(objects...)
+	return kube.NewForClients(kubeClient, loftClient, agentClient)
+}
+
+// newClientsets seeds each object into the fake clientset whose scheme knows its type
+func newClientsets(objects ...runtime.Object) (*kubefake.Clientset, *loftfake.Clientset, *agentfake.Clientset) {
+	loftScheme := runtime.NewScheme()
+	_ = loftfake.AddToScheme(loftScheme)
+	agentScheme := runtime.NewScheme()
+	_ = agentfake.AddToScheme(agentScheme)
+
+	var kubeObjects, loftObjects, agentObjects []runtime.Object
+	for _, obj := range objects {
+		if _, _, err := loftScheme.ObjectKinds(obj); err == nil {
+			loftObjects = append(loftObjects, obj)
+		} else if _, _, err := agentScheme.ObjectKinds(obj); err == nil {
+			agentObjects = append(agentObjects, obj)
+		} else {
+			kubeObjects = append(kubeObjects, obj)
+		}
+	}
+
+	return kubefake.NewSimpleClientset(kubeObjects...), loftfake.NewSimpleClientset(loftObjects...), agentfake.NewSimpleClientset(agentObjects...)
+}
+
+// SetSpaceInstance seeds the client returned by SpaceInstance with the given objects
+func (c *Client) SetSpaceInstance(project, name string, objects ...runtime.Object) {
+	c.setInstance(spaceInstancePath(project, name), objects...)
+}
+
+// SetVirtualClusterInstance seeds the client returned by VirtualClusterInstance with the given objects
+func (c *Client) SetVirtualClusterInstance(project, name string, objects ...runtime.Object) {
+	c.setInstance(virtualClusterInstancePath(project, name), objects...)
+}
+
+// SetCluster seeds the client returned by Cluster with the given objects
+func (c *Client) SetCluster(cluster string, objects ...runtime.Object) {
+	c.setInstance(clusterPath(cluster), objects...)
+}
+
+// SetVirtualCluster seeds the client returned by VirtualCluster with the given objects
+func (c *Client) SetVirtualCluster(cluster, namespace, virtualCluster string, objects ...runtime.Object) {
+	c.setInstance(virtualClusterPath(cluster, namespace, virtualCluster), objects...)
+}
+
+func (c *Client) setInstance(path string, objects ...runtime.Object) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.instances[path] = NewKube(objects...)
+}
+
+// instance returns the client for the given path, instances that were not seeded are empty
+func (c *Client) instance(path string) (kube.Interface, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	instance, ok := c.instances[path]
+	if !ok {
+		instance = NewKube()
+		c.instances[path] = instance
+	}
+
+	return instance, nil
+}
+
+func (c *Client) restConfig(path string) (*rest.Config, error) {
+	return &rest.Config{
+		Host:        c.ClientConfig.Host + path,
+		BearerToken: c.ClientConfig.AccessKey,
+	}, nil
+}
+
+func (c *Client) Management() (kube.Interface, error) {
+	return kube.NewForClients(c.Kube, c.Loft, c.Agent), nil
+}
+
+func (c *Client) ManagementConfig() (*rest.Config, error) {
+	return c.restConfig("/kubernetes/management")
+}
+
+func (c *Client) RefreshSelf(ctx context.Context) error {
+	projectNamespacePrefix := projectutil.LegacyProjectNamespacePrefix
+	if c.CurrentSelf.Status.ProjectNamespacePrefix != nil {
+		projectNamespacePrefix = *c.CurrentSelf.Status.ProjectNamespacePrefix
+	}
+
+	projectutil.SetProjectNamespacePrefix(projectNamespacePrefix)
+	return nil
+}
+
+func (c *Client) Self() *managementv1.Self {
+	return c.CurrentSelf.DeepCopy()
+}
+
+func (c *Client) SpaceInstance(project, name string) (kube.Interface, error) {
+	return c.instance(spaceInstancePath(project, name))
+}
+
+func (c *Client) SpaceInstanceConfig(project, name string) (*rest.Config, error) {
+	return c.restConfig(spaceInstancePath(project, name))
+}
+
+func (c *Client) VirtualClusterInstance(project, name string) (kube.Interface, error) {
+	return c.instance(virtualClusterInstancePath(project, name))
+}
+
+func (c *Client) VirtualClusterInstanceConfig(project, name string) (*rest.Config, error) {
+	return c.restConfig(virtualClusterInstancePath(project, name))
+}
+
+func (c *Client) Cluster(cluster string) (kube.Interface, error) {
+	return c.instance(clusterPath(cluster))
+}
+
+func (c *Client) ClusterConfig(cluster string) (*rest.Config, error) {
+	return c.restConfig(clusterPath(cluster))
+}
+
+func (c *Client) VirtualCluster(cluster, namespace, virtualCluster string) (kube.Interface, error) {
+	return c.instance(virtualClusterPath(cluster, namespace, virtualCluster))
+}
+
+func (c *Client) VirtualClusterConfig(cluster, namespace, virtualCluster string) (*rest.Config, error) {
+	return c.restConfig(virtualClusterPath(cluster, namespace, virtualCluster))
+}
+
+func (c *Client) Login(host string, insecure bool, log log.Logger) error {
+	return c.LoginWithOptions(context.Background(), host, insecure, client.LoginOptions{}, log)
+}
+
+func (c *Client) LoginWithOptions(ctx context.Context, host string, insecure bool, options client.LoginOptions, log log.Logger) error {
+	return c.LoginRaw(host, "fake-access-key", insecure)
+}
+
+func (c *Client) LoginWithAccessKey(host, accessKey string, insecure bool) error {
+	return c.LoginRaw(host, accessKey, insecure)
+}
+
+func (c *Client) LoginRaw(host, accessKey string, insecure bool) error {
+	c.ClientConfig.Host = host
+	c.ClientConfig.AccessKey = accessKey
+	c.ClientConfig.Insecure = insecure
+	return nil
+}
+
+func (c *Client) Logout(ctx context.Context) error {
+	c.ClientConfig.AccessKey = ""
+	return nil
+}
+
+func (c *Client) Version() (*auth.Version, error) {
+	return c.CurrentVersion, nil
+}
+
+func (c *Client) Config() *client.Config {
+	return c.ClientConfig
+}
+
+func (c *Client) DirectClusterEndpointToken(forceRefresh bool) (string, error) {
+	if c.ClientConfig.DirectClusterEndpointToken == "" {
+		return "", fmt.Errorf("no direct cluster endpoint token configured")
+	}
+
+	return c.ClientConfig.DirectClusterEndpointToken, nil
+}
+
+func (c *Client) VirtualClusterAccessPointCertificate(project, virtualCluster string, forceRefresh bool) (string, string, error) {
+	contextName := kubeconfig.VirtualClusterInstanceContextName(project, virtualCluster)
+	certificates, ok := c.ClientConfig.VirtualClusterAccessPointCertificates[contextName]
+	if !ok {
+		return "", "", fmt.Errorf("no certificates configured for %s", contextName)
+	}
+
+	return certificates.CertificateData, certificates.KeyData, nil
+}
+
+func (c *Client) Save() error {
+	c.Saves++
+	return nil
+}
+
+func spaceInstancePath(project, name string) string {
+	return "/kubernetes/project/" + project + "/space/" + name
+}
+
+func virtualClusterInstancePath(project, name string) string {
+	return "/kubernetes/project/" + project + "/virtualcluster/" + name
+}
+
+func clusterPath(cluster string) string {
+	return "/kubernetes/cluster/" + cluster
+}
+
+func virtualClusterPath(cluster, namespace, virtualCluster string) string {
+	return "/kubernetes/virtualcluster/" + cluster + "/" + namespace + "/" + virtualCluster
+}
@@ -38,6 +38,15 @@ type SpaceInstanceProject struct {
 	Project       *managementv1.Project
 }
 
+// InstanceListOptions restricts the listed space and virtual cluster instances
+type InstanceListOptions struct {
+	// Projects are the projects to list the instances of, all projects if empty
+	Projects []string
+
+	// LabelSelector is passed to the list calls of every project
+	LabelSelector string
+}
+
 func SelectVirtualClusterTemplate(ctx context.Context, baseClient client.Client, projectName, templateName string, log log.Logger) (*managementv1.VirtualClusterTemplate, error) {
 	managementClient, err := baseClient.Management()
 	if err != nil {
@@ -179,24 +188,34 @@ func SelectVirtualClusterInstanceOrVirtualCluster(ctx context.Context, baseClien
 		}
 	}
 
-	// gather space instances in those projects
-	var virtualClusters []*VirtualClusterInstanceProject
-	for _, p := range projects {
+	// gather virtual cluster instances in those projects, projects that can't be accessed are skipped
+	projectVirtualClusters := make([][]*VirtualClusterInstanceProject, len(projects))
+	err = forEach(ctx, len(projects), func(ctx context.Context, idx int) error {
+		var err error
 		if virtualClusterName != "" {
-			virtualClusterInstance, err := getProjectVirtualClusterInstance(ctx, managementClient, p, virtualClusterName)
-			if err != nil {
-				continue
+			var virtualClusterInstance *VirtualClusterInstanceProject
+			virtualClusterInstance, err = getProjectVirtualClusterInstance(ctx, managementClient, projects[idx], virtualClusterName)
+			if virtualClusterInstance != nil {
+				projectVirtualClusters[idx] = []*VirtualClusterInstanceProject{virtualClusterInstance}
 			}
-
-			virtualClusters = append(virtualClusters, virtualClusterInstance)
 		} else {
-			projectVirtualClusters, err := getProjectVirtualClusterInstances(ctx, managementClient, p)
-			if err != nil {
-				continue
-			}
-
-			virtualClusters = append(virtualClusters, projectVirtualClusters...)
+			projectVirtualClusters[idx], err = getProjectVirtualClusterInstances(ctx, managementClient, projects[idx], "")
+		}
+		if err != nil && !kerrors.IsForbidden(err) && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("get virtual clusters of project %s: %w", projects[idx].Name, err)
 		}
+
+		return nil
+	})
+	if ctx.Err() != nil {
+		return "", "", "", "", ctx.Err()
+	} else if err != nil {
+		return "", "", "", "", err
+	}
+
+	var virtualClusters []*VirtualClusterInstanceProject
+	for _, v := range projectVirtualClusters {
+		virtualClusters = append(virtualClusters, v...)
 	}
 
 	// get unformatted options
@@ -271,24 +290,34 @@ func SelectSpaceInstanceOrSpace(ctx context.Context, baseClient client.Client, s
 		}
 	}
 
-	// gather space instances in those projects
-	var spaces []*SpaceInstanceProject
-	for _, p := range projects {
+	// gather space instances in those projects, projects that can't be accessed are skipped
+	projectSpaces := make([][]*SpaceInstanceProject, len(projects))
+	err = forEach(ctx, len(projects), func(ctx context.Context, idx int) error {
+		var err error
 		if spaceName != "" {
-			spaceInstance, err := getProjectSpaceInstance(ctx, managementClient, p, spaceName)
-			if err != nil {
-				continue
+			var spaceInstance *SpaceInstanceProject
+			spaceInstance, err = getProjectSpaceInstance(ctx, managementClient, projects[idx], spaceName)
+			if spaceInstance != nil {
+				projectSpaces[idx] = []*SpaceInstanceProject{spaceInstance}
 			}
-
-			spaces = append(spaces, spaceInstance)
 		} else {
-			projectSpaceInstances, err := getProjectSpaceInstances(ctx, managementClient, p)
-			if err != nil {
-				continue
-			}
-
-			spaces = append(spaces, projectSpaceInstances...)
+			projectSpaces[idx], err = getProjectSpaceInstances(ctx, managementClient, projects[idx], "")
+		}
+		if err != nil && !kerrors.IsForbidden(err) && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("get spaces of project %s: %w", projects[idx].Name, err)
 		}
+
+		return nil
+	})
+	if ctx.Err() != nil {
+		return "", "", "", ctx.Err()
+	} else if err != nil {
+		return "", "", "", err
+	}
+
+	var spaces []*SpaceInstanceProject
+	for _, s := range projectSpaces {
+		spaces = append(spaces, s...)
 	}
 
 	// get unformatted options
@@ -637,25 +666,39 @@ func SelectClusterUserOrTeam(ctx context.Context, baseClient client.Client, clus
 }
 
 func GetVirtualClusterInstances(ctx context.Context, baseClient client.Client) ([]*VirtualClusterInstanceProject, error) {
+	return ListVirtualClusterInstances(ctx, baseClient, InstanceListOptions{})
+}
+
+// ListVirtualClusterInstances returns the virtual cluster instances of the projects that match the given options.
+// The projects are listed in parallel and access is taken from the extended list response, so no
+// access review is needed per instance.
+func ListVirtualClusterInstances(ctx context.Context, baseClient client.Client, options InstanceListOptions) ([]*VirtualClusterInstanceProject, error) {
 	managementClient, err := baseClient.Management()
 	if err != nil {
 		return nil, err
 	}
 
-	projectList, err := managementClient.Loft().ManagementV1().Projects().List(ctx, metav1.ListOptions{})
+	projects, err := GetProjects(ctx, managementClient, options.Projects)
 	if err != nil {
 		return nil, err
 	}
 
-	var retVClusters []*VirtualClusterInstanceProject
-	for _, project := range projectList.Items {
-		p := &project
-
-		virtualClusterInstances, err := getProjectVirtualClusterInstances(ctx, managementClient, p)
+	projectVirtualClusters := make([][]*VirtualClusterInstanceProject, len(projects))
+	err = forEach(ctx, len(projects), func(ctx context.Context, idx int) error {
+		virtualClusterInstances, err := getProjectVirtualClusterInstances(ctx, managementClient, projects[idx], options.LabelSelector)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
+		projectVirtualClusters[idx] = virtualClusterInstances
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var retVClusters []*VirtualClusterInstanceProject
+	for _, virtualClusterInstances := range projectVirtualClusters {
 		retVClusters = append(retVClusters, virtualClusterInstances...)
 	}
 
@@ -690,29 +733,82 @@ func CanAccessInstance(ctx context.Context, managementClient kube.Interface, nam
 }
 
 func GetSpaceInstances(ctx context.Context, baseClient client.Client) ([]*SpaceInstanceProject, error) {
+	return ListSpaceInstances(ctx, baseClient, InstanceListOptions{})
+}
+
+// ListSpaceInstances returns the space instances of the projects that match the given options. The
+// projects are listed in parallel and access is taken from the extended list response, so no
+// access review is needed per instance.
+func ListSpaceInstances(ctx context.Context, baseClient client.Client, options InstanceListOptions) ([]*SpaceInstanceProject, error) {
 	managementClient, err := baseClient.Management()
 	if err != nil {
 		return nil, err
 	}
 
-	projectList, err := managementClient.Loft().ManagementV1().Projects().List(ctx, metav1.ListOptions{})
+	projects, err := GetProjects(ctx, managementClient, options.Projects)
+	if err != nil {
+		return nil, err
+	}
+
+	projectSpaces := make([][]*SpaceInstanceProject, len(projects))
+	err = forEach(ctx, len(projects), func(ctx context.Context, idx int) error {
+		spaceInstances, err := getProjectSpaceInstances(ctx, managementClient, projects[idx], options.LabelSelector)
+		if err != nil {
+			return err
+		}
+
+		projectSpaces[idx] = spaceInstances
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	var retSpaces []*SpaceInstanceProject
-	for _, project := range projectList.Items {
-		p := &project
+	for _, spaceInstances := range projectSpaces {
+		retSpaces = append(retSpaces, spaceInstances...)
+	}
+
+	return retSpaces, nil
+}
 
-		spaceInstances, err := getProjectSpaceInstances(ctx, managementClient, p)
+// GetProjects returns the projects with the given names or all projects if no names are given
+func GetProjects(ctx context.Context, managementClient kube.Interface, projectNames []string) ([]*managementv1.Project, error) {
+	var projects []*managementv1.Project
+	if len(projectNames) == 0 {
+		err := listPages(metav1.ListOptions{}, func(options metav1.ListOptions) (string, error) {
+			projectList, err := managementClient.Loft().ManagementV1().Projects().List(ctx, options)
+			if err != nil {
+				return "", err
+			}
+
+			for idx := range projectList.Items {
+				projects = append(projects, &projectList.Items[idx])
+			}
+
+			return projectList.Continue, nil
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		retSpaces = append(retSpaces, spaceInstances...)
+		return projects, nil
 	}
 
-	return retSpaces, nil
+	for _, projectName := range projectNames {
+		project, err := managementClient.Loft().ManagementV1().Projects().Get(ctx, projectName, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("couldn't find or access project %s: %w", projectName, err)
+			}
+
+			return nil, err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
 }
 
 type ProjectProjectSecret struct {
@@ -721,47 +817,57 @@ type ProjectProjectSecret struct {
 }
 
 func GetProjectSecrets(ctx context.Context, managementClient kube.Interface, projectNames ...string) ([]*ProjectProjectSecret, error) {
-	var projects []*managementv1.Project
-	if len(projectNames) == 0 {
-		projectList, err := managementClient.Loft().ManagementV1().Projects().List(ctx, metav1.ListOptions{})
-		if err != nil {
-			return nil, err
-		}
+	projects, err := GetProjects(ctx, managementClient, projectNames)
+	if err != nil {
+		return nil, err
+	}
 
-		for idx := range projectList.Items {
-			projectItem := projectList.Items[idx]
-			projects = append(projects, &projectItem)
-		}
-	} else {
-		for _, projectName := range projectNames {
-			project, err := managementClient.Loft().ManagementV1().Projects().Get(ctx, projectName, metav1.GetOptions{})
+	projectSecrets := make([][]*ProjectProjectSecret, len(projects))
+	err = forEach(ctx, len(projects), func(ctx context.Context, idx int) error {
+		return listPages(metav1.ListOptions{}, func(options metav1.ListOptions) (string, error) {
+			projectSecretList, err := managementClient.Loft().ManagementV1().ProjectSecrets(projectutil.ProjectNamespace(projects[idx].Name)).List(ctx, options)
 			if err != nil {
-				return nil, err
+				return "", err
 			}
 
-			projects = append(projects, project)
-		}
+			for _, projectSecret := range projectSecretList.Items {
+				projectSecrets[idx] = append(projectSecrets[idx], &ProjectProjectSecret{
+					ProjectSecret: projectSecret,
+					Project:       projects[idx].Name,
+				})
+			}
+
+			return projectSecretList.Continue, nil
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	var retSecrets []*ProjectProjectSecret
-	for _, project := range projects {
-		projectSecrets, err := managementClient.Loft().ManagementV1().ProjectSecrets(projectutil.ProjectNamespace(project.Name)).List(ctx, metav1.ListOptions{})
+	var secrets []*ProjectProjectSecret
+	for _, s := range projectSecrets {
+		secrets = append(secrets, s...)
+	}
+
+	// listing project secrets doesn't imply use access, so every secret is reviewed
+	canAccess := make([]bool, len(secrets))
+	err = forEach(ctx, len(secrets), func(ctx context.Context, idx int) error {
+		allowed, err := CanAccessProjectSecret(ctx, managementClient, secrets[idx].ProjectSecret.Namespace, secrets[idx].ProjectSecret.Name)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		for _, projectSecret := range projectSecrets.Items {
-			canAccess, err := CanAccessProjectSecret(ctx, managementClient, projectSecret.Namespace, projectSecret.Name)
-			if err != nil {
-				return nil, err
-			} else if !canAccess {
-				continue
-			}
+		canAccess[idx] = allowed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			retSecrets = append(retSecrets, &ProjectProjectSecret{
-				ProjectSecret: projectSecret,
-				Project:       project.Name,
-			})
+	var retSecrets []*ProjectProjectSecret
+	for idx, secret := range secrets {
+		if canAccess[idx] {
+			retSecrets = append(retSecrets, secret)
 		}
 	}
 
@@ -785,29 +891,45 @@ func GetSpaces(ctx context.Context, baseClient client.Client, log log.Logger) ([
 		return nil, err
 	}
 
-	spaceList := []ClusterSpace{}
-	for _, cluster := range clusterList.Items {
+	clusterSpaces := make([][]ClusterSpace, len(clusterList.Items))
+	err = forEach(ctx, len(clusterList.Items), func(ctx context.Context, idx int) error {
+		cluster := &clusterList.Items[idx]
 		clusterClient, err := baseClient.Cluster(cluster.Name)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		spaces, err := clusterClient.Agent().ClusterV1().Spaces().List(ctx, metav1.ListOptions{})
-		if err != nil {
-			if kerrors.IsForbidden(err) {
-				continue
+		err = listPages(metav1.ListOptions{}, func(options metav1.ListOptions) (string, error) {
+			spaces, err := clusterClient.Agent().ClusterV1().Spaces().List(ctx, options)
+			if err != nil {
+				return "", err
 			}
 
-			log.Warnf("Error retrieving spaces from cluster %s: %v", clihelper.GetDisplayName(cluster.Name, cluster.Spec.DisplayName), err)
-			continue
-		}
+			for _, space := range spaces.Items {
+				clusterSpaces[idx] = append(clusterSpaces[idx], ClusterSpace{
+					Space:   space,
+					Cluster: cluster.Name,
+				})
+			}
 
-		for _, space := range spaces.Items {
-			spaceList = append(spaceList, ClusterSpace{
-				Space:   space,
-				Cluster: cluster.Name,
-			})
+			return spaces.Continue, nil
+		})
+		if err != nil {
+			clusterSpaces[idx] = nil
+			if !kerrors.IsForbidden(err) {
+				log.Warnf("Error retrieving spaces from cluster %s: %v", clihelper.GetDisplayName(cluster.Name, cluster.Spec.DisplayName), err)
+			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spaceList := []ClusterSpace{}
+	for _, spaces := range clusterSpaces {
+		spaceList = append(spaceList, spaces...)
 	}
 	sort.Slice(spaceList, func(i, j int) bool {
 		return spaceList[i].Name < spaceList[j].Name
@@ -833,29 +955,45 @@ func GetVirtualClusters(ctx context.Context, baseClient client.Client, log log.L
 		return nil, err
 	}
 
-	virtualClusterList := []ClusterVirtualCluster{}
-	for _, cluster := range clusterList.Items {
+	clusterVirtualClusters := make([][]ClusterVirtualCluster, len(clusterList.Items))
+	err = forEach(ctx, len(clusterList.Items), func(ctx context.Context, idx int) error {
+		cluster := &clusterList.Items[idx]
 		clusterClient, err := baseClient.Cluster(cluster.Name)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		virtualClusters, err := clusterClient.Agent().ClusterV1().VirtualClusters("").List(ctx, metav1.ListOptions{})
-		if err != nil {
-			if kerrors.IsForbidden(err) {
-				continue
+		err = listPages(metav1.ListOptions{}, func(options metav1.ListOptions) (string, error) {
+			virtualClusters, err := clusterClient.Agent().ClusterV1().VirtualClusters("").List(ctx, options)
+			if err != nil {
+				return "", err
 			}
 
-			log.Warnf("Error retrieving virtual clusters from cluster %s: %v", clihelper.GetDisplayName(cluster.Name, cluster.Spec.DisplayName), err)
-			continue
-		}
+			for _, virtualCluster := range virtualClusters.Items {
+				clusterVirtualClusters[idx] = append(clusterVirtualClusters[idx], ClusterVirtualCluster{
+					VirtualCluster: virtualCluster,
+					Cluster:        cluster.Name,
+				})
+			}
 
-		for _, virtualCluster := range virtualClusters.Items {
-			virtualClusterList = append(virtualClusterList, ClusterVirtualCluster{
-				VirtualCluster: virtualCluster,
-				Cluster:        cluster.Name,
-			})
+			return virtualClusters.Continue, nil
+		})
+		if err != nil {
+			clusterVirtualClusters[idx] = nil
+			if !kerrors.IsForbidden(err) {
+				log.Warnf("Error retrieving virtual clusters from cluster %s: %v", clihelper.GetDisplayName(cluster.Name, cluster.Spec.DisplayName), err)
+			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	virtualClusterList := []ClusterVirtualCluster{}
+	for _, virtualClusters := range clusterVirtualClusters {
+		virtualClusterList = append(virtualClusterList, virtualClusters...)
 	}
 	sort.Slice(virtualClusterList, func(i, j int) bool {
 		return virtualClusterList[i].Name < virtualClusterList[j].Name
@@ -1051,6 +1189,7 @@ func formatOptions(format string, options [][]string) []string {
 	return retOptions
 }
 
+// getProjectSpaceInstance returns nil if the user is not allowed to use the space instance
 func getProjectSpaceInstance(ctx context.Context, managementClient kube.Interface, project *managementv1.Project, spaceName string) (*SpaceInstanceProject, error) {
 	spaceInstance := &managementv1.SpaceInstance{}
 	err := managementClient.Loft().ManagementV1().RESTClient().
@@ -1067,7 +1206,7 @@ func getProjectSpaceInstance(ctx context.Context, managementClient kube.Interfac
 	}
 
 	if !spaceInstance.Status.CanUse {
-		return nil, fmt.Errorf("no use access")
+		return nil, nil
 	}
 
 	return &SpaceInstanceProject{
@@ -1076,35 +1215,44 @@ func getProjectSpaceInstance(ctx context.Context, managementClient kube.Interfac
 	}, nil
 }
 
-func getProjectSpaceInstances(ctx context.Context, managementClient kube.Interface, project *managementv1.Project) ([]*SpaceInstanceProject, error) {
-	spaceInstanceList := &managementv1.SpaceInstanceList{}
-	err := managementClient.Loft().ManagementV1().RESTClient().
-		Get().
-		Resource("spaceinstances").
-		Namespace(projectutil.ProjectNamespace(project.Name)).
-		VersionedParams(&metav1.ListOptions{}, scheme.ParameterCodec).
-		Param("extended", "true").
-		Do(ctx).
-		Into(spaceInstanceList)
-	if err != nil {
-		return nil, err
-	}
-
+func getProjectSpaceInstances(ctx context.Context, managementClient kube.Interface, project *managementv1.Project, labelSelector string) ([]*SpaceInstanceProject, error) {
 	var spaces []*SpaceInstanceProject
-	for _, spaceInstance := range spaceInstanceList.Items {
-		if !spaceInstance.Status.CanUse {
-			continue
+	err := listPages(metav1.ListOptions{LabelSelector: labelSelector}, func(options metav1.ListOptions) (string, error) {
+		spaceInstanceList := &managementv1.SpaceInstanceList{}
+		err := managementClient.Loft().ManagementV1().RESTClient().
+			Get().
+			Resource("spaceinstances").
+			Namespace(projectutil.ProjectNamespace(project.Name)).
+			VersionedParams(&options, scheme.ParameterCodec).
+			Param("extended", "true").
+			Do(ctx).
+			Into(spaceInstanceList)
+		if err != nil {
+			return "", err
 		}
 
-		s := spaceInstance
-		spaces = append(spaces, &SpaceInstanceProject{
-			SpaceInstance: &s,
-			Project:       project,
-		})
+		for _, spaceInstance := range spaceInstanceList.Items {
+			if !spaceInstance.Status.CanUse {
+				continue
+			}
+
+			s := spaceInstance
+			spaces = append(spaces, &SpaceInstanceProject{
+				SpaceInstance: &s,
+				Project:       project,
+			})
+		}
+
+		return spaceInstanceList.Continue, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
 	return spaces, nil
 }
 
+// getProjectVirtualClusterInstance returns nil if the user is not allowed to use the virtual cluster instance
 func getProjectVirtualClusterInstance(ctx context.Context, managementClient kube.Interface, project *managementv1.Project, virtualClusterName string) (*VirtualClusterInstanceProject, error) {
 	virtualClusterInstance := &managementv1.VirtualClusterInstance{}
 	err := managementClient.Loft().ManagementV1().RESTClient().
@@ -1121,7 +1269,7 @@ func getProjectVirtualClusterInstance(ctx context.Context, managementClient kube
 	}
 
 	if !virtualClusterInstance.Status.CanUse {
-		return nil, fmt.Errorf("no use access")
+		return nil, nil
 	}
 
 	return &VirtualClusterInstanceProject{
@@ -1130,31 +1278,39 @@ func getProjectVirtualClusterInstance(ctx context.Context, managementClient kube
 	}, nil
 }
 
-func getProjectVirtualClusterInstances(ctx context.Context, managementClient kube.Interface, project *managementv1.Project) ([]*VirtualClusterInstanceProject, error) {
-	virtualClusterInstanceList := &managementv1.VirtualClusterInstanceList{}
-	err := managementClient.Loft().ManagementV1().RESTClient().
-		Get().
-		Resource("virtualclusterinstances").
-		Namespace(projectutil.ProjectNamespace(project.Name)).
-		VersionedParams(&metav1.ListOptions{}, scheme.ParameterCodec).
-		Param("extended", "true").
-		Do(ctx).
-		Into(virtualClusterInstanceList)
-	if err != nil {
-		return nil, err
-	}
-
+func getProjectVirtualClusterInstances(ctx context.Context, managementClient kube.Interface, project *managementv1.Project, labelSelector string) ([]*VirtualClusterInstanceProject, error) {
 	var virtualClusters []*VirtualClusterInstanceProject
-	for _, virtualClusterInstance := range virtualClusterInstanceList.Items {
-		if !virtualClusterInstance.Status.CanUse {
-			continue
+	err := listPages(metav1.ListOptions{LabelSelector: labelSelector}, func(options metav1.ListOptions) (string, error) {
+		virtualClusterInstanceList := &managementv1.VirtualClusterInstanceList{}
+		err := managementClient.Loft().ManagementV1().RESTClient().
+			Get().
+			Resource("virtualclusterinstances").
+			Namespace(projectutil.ProjectNamespace(project.Name)).
+			VersionedParams(&options, scheme.ParameterCodec).
+			Param("extended", "true").
+			Do(ctx).
+			Into(virtualClusterInstanceList)
+		if err != nil {
+			return "", err
 		}
 
-		v := virtualClusterInstance
-		virtualClusters = append(virtualClusters, &VirtualClusterInstanceProject{
-			VirtualCluster: &v,
-			Project:        project,
-		})
+		for _, virtualClusterInstance := range virtualClusterInstanceList.Items {
+			if !virtualClusterInstance.Status.CanUse {
+				continue
+			}
+
+			v := virtualClusterInstance
+			virtualClusters = append(virtualClusters, &VirtualClusterInstanceProject{
+				VirtualCluster: &v,
+				Project:        project,
+			})
+		}
+
+		return virtualClusterInstanceList.Continue, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
 	return virtualClusters, nil
 }
@@ -0,0 +1,83 @@
+package helper
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	clusterv1 "github.com/loft-sh/agentapi/v4/pkg/apis/loft/cluster/v1"
+	managementv1 "github.com/loft-sh/api/v4/pkg/apis/management/v1"
+	"github.com/loft-sh/loftctl/v4/pkg/client/fake"
+	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
+	"gotest.tools/v3/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetProjectSecrets(t *testing.T) {
+	baseClient := fake.NewClient(nil,
+		&managementv1.Project{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		&managementv1.Project{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+		&managementv1.ProjectSecret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: projectutil.ProjectNamespace("a")}},
+		&managementv1.ProjectSecret{ObjectMeta: metav1.ObjectMeta{Name: "hidden", Namespace: projectutil.ProjectNamespace("a")}},
+		&managementv1.ProjectSecret{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: projectutil.ProjectNamespace("b")}},
+	)
+	baseClient.Authorize = func(attributes *authorizationv1.ResourceAttributes) bool {
+		return attributes.Name != "hidden"
+	}
+	managementClient, err := baseClient.Management()
+	assert.NilError(t, err)
+
+	secrets, err := GetProjectSecrets(context.Background(), managementClient)
+	assert.NilError(t, err)
+	names := []string{}
+	for _, secret := range secrets {
+		names = append(names, secret.Project+"/"+secret.ProjectSecret.Name)
+	}
+	sort.Strings(names)
+	assert.DeepEqual(t, names, []string{"a/db", "b/api"})
+
+	secrets, err = GetProjectSecrets(context.Background(), managementClient, "b")
+	assert.NilError(t, err)
+	assert.Equal(t, len(secrets), 1)
+	assert.Equal(t, secrets[0].ProjectSecret.Name, "api")
+
+	_, err = GetProjectSecrets(context.Background(), managementClient, "missing")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	baseClient := fake.NewClient(&managementv1.Self{
+		Status: managementv1.SelfStatus{
+			User: &managementv1.UserInfo{EntityInfo: clusterv1.EntityInfo{Name: "admin"}},
+		},
+	})
+	managementClient, err := baseClient.Management()
+	assert.NilError(t, err)
+
+	user, team, err := GetCurrentUser(context.Background(), managementClient)
+	assert.NilError(t, err)
+	assert.Equal(t, user.Name, "admin")
+	assert.Assert(t, team == nil)
+
+	baseClient.CurrentSelf = &managementv1.Self{}
+	_, _, err = GetCurrentUser(context.Background(), managementClient)
+	assert.ErrorContains(t, err, "no user or team name returned")
+}
+
+func TestCanAccessInstance(t *testing.T) {
+	baseClient := fake.NewClient(nil)
+	baseClient.Authorize = func(attributes *authorizationv1.ResourceAttributes) bool {
+		return attributes.Verb == "use" && attributes.Resource == "virtualclusterinstances" && attributes.Name == "allowed"
+	}
+	managementClient, err := baseClient.Management()
+	assert.NilError(t, err)
+
+	canAccess, err := CanAccessInstance(context.Background(), managementClient, "loft-p-default", "allowed", "virtualclusterinstances")
+	assert.NilError(t, err)
+	assert.Assert(t, canAccess)
+
+	canAccess, err = CanAccessInstance(context.Background(), managementClient, "loft-p-default", "denied", "virtualclusterinstances")
+	assert.NilError(t, err)
+	assert.Assert(t, !canAccess)
+}
@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaxConcurrentRequests is the number of requests that are sent in parallel when
+// objects are gathered from several projects or clusters
+var MaxConcurrentRequests = 10
+
+// listPageSize is the number of objects that are requested per page of a list call
+const listPageSize = 500
+
+// forEach calls fn for the indexes 0 to n-1 with at most MaxConcurrentRequests calls in parallel.
+// The first error cancels the context of the other calls and is returned.
+func forEach(ctx context.Context, n int, fn func(ctx context.Context, idx int) error) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(MaxConcurrentRequests)
+	for idx := 0; idx < n; idx++ {
+		idx := idx
+		group.Go(func() error {
+			return fn(groupCtx, idx)
+		})
+	}
+
+	return group.Wait()
+}
+
+// listPages calls list with the continue token of the previous page until list returns
+// an empty continue token. Servers that don't support pagination return everything at once.
+func listPages(options metav1.ListOptions, list func(options metav1.ListOptions) (string, error)) error {
+	options.Limit = listPageSize
+	for {
+		continueToken, err := list(options)
+		if err != nil {
+			return err
+		} else if continueToken == "" {
+			return nil
+		}
+
+		options.Continue = continueToken
+	}
+}
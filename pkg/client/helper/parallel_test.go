@@ -0,0 +1,58 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestForEach(t *testing.T) {
+	var running, maxRunning int32
+	results := make([]int, 25)
+	err := forEach(context.Background(), len(results), func(ctx context.Context, idx int) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		results[idx] = idx * 2
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, maxRunning <= int32(MaxConcurrentRequests), "%d requests ran in parallel", maxRunning)
+	for idx, result := range results {
+		assert.Equal(t, result, idx*2)
+	}
+
+	err = forEach(context.Background(), 5, func(ctx context.Context, idx int) error {
+		if idx == 3 {
+			return errors.New("failed")
+		}
+
+		return nil
+	})
+	assert.Error(t, err, "failed")
+}
+
+func TestListPages(t *testing.T) {
+	pages := map[string]string{"": "a", "a": "b", "b": ""}
+	requested := []string{}
+	err := listPages(metav1.ListOptions{LabelSelector: "app=test"}, func(options metav1.ListOptions) (string, error) {
+		assert.Equal(t, options.Limit, int64(listPageSize))
+		assert.Equal(t, options.LabelSelector, "app=test")
+		requested = append(requested, options.Continue)
+		return pages[options.Continue], nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, requested, []string{"", "a", "b"})
+}
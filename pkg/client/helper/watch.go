@@ -0,0 +1,263 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	managementv1 "github.com/loft-sh/api/v4/pkg/apis/management/v1"
+	"github.com/loft-sh/api/v4/pkg/client/clientset_generated/clientset/scheme"
+	"github.com/loft-sh/loftctl/v4/pkg/client"
+	"github.com/loft-sh/loftctl/v4/pkg/kube"
+	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// SpaceInstanceEvent is a change of a space instance. Space instances that can no longer be
+// used are reported as deleted.
+type SpaceInstanceEvent struct {
+	Type  watch.EventType
+	Space *SpaceInstanceProject
+	Err   error
+}
+
+// VirtualClusterInstanceEvent is a change of a virtual cluster instance. Virtual cluster
+// instances that can no longer be used are reported as deleted.
+type VirtualClusterInstanceEvent struct {
+	Type           watch.EventType
+	VirtualCluster *VirtualClusterInstanceProject
+	Err            error
+}
+
+// WatchSpaceInstances returns the space instances that match the options and streams their changes
+// until the context is done, which also releases the watches. The channel is closed afterwards or
+// after an event with an error. Projects created after the watch was started are not watched.
+func WatchSpaceInstances(ctx context.Context, baseClient client.Client, options InstanceListOptions) ([]*SpaceInstanceProject, <-chan SpaceInstanceEvent, error) {
+	lists, events, err := watchProjectInstances(ctx, baseClient, options, "spaceinstances", func() runtime.Object { return &managementv1.SpaceInstanceList{} })
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var spaces []*SpaceInstanceProject
+	for _, list := range lists {
+		for idx := range list.list.(*managementv1.SpaceInstanceList).Items {
+			spaceInstance := &list.list.(*managementv1.SpaceInstanceList).Items[idx]
+			if spaceInstance.Status.CanUse {
+				spaces = append(spaces, &SpaceInstanceProject{SpaceInstance: spaceInstance, Project: list.project})
+			}
+		}
+	}
+
+	spaceEvents := make(chan SpaceInstanceEvent)
+	go func() {
+		defer close(spaceEvents)
+
+		for event := range events {
+			if event.err != nil {
+				sendEvent(ctx, spaceEvents, SpaceInstanceEvent{Err: event.err})
+				return
+			}
+
+			spaceInstance, ok := event.event.Object.(*managementv1.SpaceInstance)
+			if !ok {
+				continue
+			}
+
+			eventType := event.event.Type
+			if !spaceInstance.Status.CanUse {
+				eventType = watch.Deleted
+			}
+			if !sendEvent(ctx, spaceEvents, SpaceInstanceEvent{
+				Type:  eventType,
+				Space: &SpaceInstanceProject{SpaceInstance: spaceInstance, Project: event.project},
+			}) {
+				return
+			}
+		}
+	}()
+
+	return spaces, spaceEvents, nil
+}
+
+// WatchVirtualClusterInstances returns the virtual cluster instances that match the options and streams
+// their changes until the context is done, which also releases the watches. The channel is closed
+// afterwards or after an event with an error. Projects created after the watch was started are not watched.
+func WatchVirtualClusterInstances(ctx context.Context, baseClient client.Client, options InstanceListOptions) ([]*VirtualClusterInstanceProject, <-chan VirtualClusterInstanceEvent, error) {
+	lists, events, err := watchProjectInstances(ctx, baseClient, options, "virtualclusterinstances", func() runtime.Object { return &managementv1.VirtualClusterInstanceList{} })
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var virtualClusters []*VirtualClusterInstanceProject
+	for _, list := range lists {
+		for idx := range list.list.(*managementv1.VirtualClusterInstanceList).Items {
+			virtualClusterInstance := &list.list.(*managementv1.VirtualClusterInstanceList).Items[idx]
+			if virtualClusterInstance.Status.CanUse {
+				virtualClusters = append(virtualClusters, &VirtualClusterInstanceProject{VirtualCluster: virtualClusterInstance, Project: list.project})
+			}
+		}
+	}
+
+	virtualClusterEvents := make(chan VirtualClusterInstanceEvent)
+	go func() {
+		defer close(virtualClusterEvents)
+
+		for event := range events {
+			if event.err != nil {
+				sendEvent(ctx, virtualClusterEvents, VirtualClusterInstanceEvent{Err: event.err})
+				return
+			}
+
+			virtualClusterInstance, ok := event.event.Object.(*managementv1.VirtualClusterInstance)
+			if !ok {
+				continue
+			}
+
+			eventType := event.event.Type
+			if !virtualClusterInstance.Status.CanUse {
+				eventType = watch.Deleted
+			}
+			if !sendEvent(ctx, virtualClusterEvents, VirtualClusterInstanceEvent{
+				Type:           eventType,
+				VirtualCluster: &VirtualClusterInstanceProject{VirtualCluster: virtualClusterInstance, Project: event.project},
+			}) {
+				return
+			}
+		}
+	}()
+
+	return virtualClusters, virtualClusterEvents, nil
+}
+
+type projectList struct {
+	project *managementv1.Project
+	list    runtime.Object
+}
+
+type projectEvent struct {
+	project *managementv1.Project
+	event   watch.Event
+	err     error
+}
+
+// watchProjectInstances lists the given resource in the projects of the options and then watches each
+// project from the resource version of its list. Interrupted watches are resumed, only
+// expired resource versions and a done context end the watch.
+func watchProjectInstances(ctx context.Context, baseClient client.Client, options InstanceListOptions, resource string, newList func() runtime.Object) ([]projectList, <-chan projectEvent, error) {
+	managementClient, err := baseClient.Management()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	projects, err := GetProjects(ctx, managementClient, options.Projects)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// list all projects in parallel and then start watching from the resource version of each list
+	lists := make([]projectList, len(projects))
+	err = forEach(ctx, len(projects), func(ctx context.Context, idx int) error {
+		list := newList()
+		err := instanceRequest(managementClient, projects[idx], resource, &metav1.ListOptions{LabelSelector: options.LabelSelector}).Do(ctx).Into(list)
+		if err != nil {
+			return err
+		}
+
+		lists[idx] = projectList{project: projects[idx], list: list}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watchers := []*watchtools.RetryWatcher{}
+	for _, list := range lists {
+		watcher, err := newInstanceWatcher(ctx, managementClient, list, resource, options.LabelSelector)
+		if err != nil {
+			for _, watcher := range watchers {
+				watcher.Stop()
+			}
+
+			return nil, nil, fmt.Errorf("watch %s in project %s: %w", resource, list.project.Name, err)
+		}
+
+		watchers = append(watchers, watcher)
+	}
+
+	events := make(chan projectEvent)
+	waitGroup := sync.WaitGroup{}
+	for idx, watcher := range watchers {
+		waitGroup.Add(1)
+		go func(project *managementv1.Project, watcher *watchtools.RetryWatcher) {
+			defer waitGroup.Done()
+			defer watcher.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-watcher.ResultChan():
+					if !ok {
+						return
+					}
+
+					projectEvent := projectEvent{project: project, event: event}
+					if event.Type == watch.Error {
+						projectEvent.err = fmt.Errorf("watch %s in project %s: %w", resource, project.Name, kerrors.FromObject(event.Object))
+					}
+
+					if !sendEvent(ctx, events, projectEvent) || projectEvent.err != nil {
+						return
+					}
+				}
+			}
+		}(lists[idx].project, watcher)
+	}
+	go func() {
+		waitGroup.Wait()
+		close(events)
+	}()
+
+	return lists, events, nil
+}
+
+// newInstanceWatcher watches the project of the list from the resource version of the list
+func newInstanceWatcher(ctx context.Context, managementClient kube.Interface, list projectList, resource, labelSelector string) (*watchtools.RetryWatcher, error) {
+	listMeta, err := meta.ListAccessor(list.list)
+	if err != nil {
+		return nil, err
+	}
+
+	return watchtools.NewRetryWatcher(listMeta.GetResourceVersion(), &cache.ListWatch{
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = labelSelector
+			return instanceRequest(managementClient, list.project, resource, &options).Watch(ctx)
+		},
+	})
+}
+
+// sendEvent sends the event unless the context is done first
+func sendEvent[T any](ctx context.Context, events chan<- T, event T) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- event:
+		return true
+	}
+}
+
+func instanceRequest(managementClient kube.Interface, project *managementv1.Project, resource string, options *metav1.ListOptions) *rest.Request {
+	return managementClient.Loft().ManagementV1().RESTClient().
+		Get().
+		Resource(resource).
+		Namespace(projectutil.ProjectNamespace(project.Name)).
+		VersionedParams(options, scheme.ParameterCodec).
+		Param("extended", "true")
+}
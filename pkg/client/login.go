@@ -0,0 +1,406 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/api/v4/pkg/product"
+	"github.com/loft-sh/loftctl/v4/pkg/httputil"
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/log/survey"
+	perrors "github.com/pkg/errors"
+	"github.com/skratchdot/open-golang/open"
+)
+
+// DefaultCallbackPort is the local port the browser redirects to after the login
+const DefaultCallbackPort = 25843
+
+// loginTimeout is how long the browser login waits for the callback
+const loginTimeout = time.Minute * 10
+
+// deviceLoginTimeout is how long the device login polls if loft doesn't return an expiry
+const deviceLoginTimeout = time.Minute * 15
+
+// slowDownInterval is added to the poll interval of the device login if loft asks to slow down
+var slowDownInterval = time.Second * 5
+
+// errDeviceLoginUnsupported is returned if the loft instance has no device login endpoint
+var errDeviceLoginUnsupported = errors.New("device login is not supported")
+
+// LoginOptions configure how the access key is obtained during the login
+type LoginOptions struct {
+	// Headless prints a url and a user code to complete the login on another
+	// device instead of opening a browser
+	Headless bool
+
+	// CallbackPort is the local port the browser redirects to after the login.
+	// If 0, a random free port is used.
+	CallbackPort int
+
+	// ValidateState sends a random state to the login page and rejects callbacks that
+	// don't return it, so other local processes or websites can't inject an access key
+	ValidateState bool
+}
+
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"deviceCode"`
+	UserCode                string `json:"userCode"`
+	VerificationURI         string `json:"verificationUri"`
+	VerificationURIComplete string `json:"verificationUriComplete,omitempty"`
+	ExpiresIn               int    `json:"expiresIn"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+type deviceTokenResponse struct {
+	AccessKey string `json:"accessKey,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (c *client) LoginWithOptions(ctx context.Context, host string, insecure bool, options LoginOptions, log log.Logger) error {
+	err := verifyHost(host)
+	if err != nil {
+		return err
+	}
+
+	tlsOptions := c.config.TLSOptions()
+	tlsOptions.Insecure = insecure
+	tlsConfig, err := tlsOptions.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	transport := httputil.CloneDefaultTransport()
+	transport.TLSClientConfig = tlsConfig
+	httpClient := &http.Client{Transport: tracer.Wrap(transport)}
+
+	var accessKey string
+	if options.Headless {
+		accessKey, err = headlessLogin(ctx, httpClient, host, log)
+	} else {
+		accessKey, err = browserLogin(ctx, httpClient, host, insecure, options, log)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.LoginWithAccessKey(host, accessKey, insecure)
+}
+
+// browserLogin opens the login page in the browser and waits for the
+// redirect to the local callback server
+func browserLogin(ctx context.Context, httpClient *http.Client, host string, insecure bool, options LoginOptions, log log.Logger) (string, error) {
+	state := ""
+	if options.ValidateState {
+		var err error
+		state, err = newState()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	listeners, err := listenLoopback(options.CallbackPort, log)
+	if err != nil {
+		return "", fmt.Errorf("start login callback server on port %d: %w. Please use --callback-port to choose another port or --headless to login on another device", options.CallbackPort, err)
+	}
+
+	// a custom port is only used if the login page accepts it, otherwise the callback server
+	// falls back to the default port loft always redirects to
+	port := listeners[0].Addr().(*net.TCPAddr).Port
+	loginURL := buildLoginURL(host, port, state)
+	rejected := (port != DefaultCallbackPort || state != "") && loginURLRejected(ctx, httpClient, loginURL, log)
+	if rejected && port != DefaultCallbackPort {
+		log.Infof("%s doesn't support a custom callback port, using port %d instead", product.DisplayName(), DefaultCallbackPort)
+		closeListeners(listeners)
+
+		listeners, err = listenLoopback(DefaultCallbackPort, log)
+		if err != nil {
+			return "", fmt.Errorf("start login callback server on port %d: %w. Please use --headless to login on another device", DefaultCallbackPort, err)
+		}
+
+		loginURL = buildLoginURL(host, DefaultCallbackPort, state)
+		rejected = state != "" && loginURLRejected(ctx, httpClient, loginURL, log)
+	}
+	if rejected {
+		closeListeners(listeners)
+		return "", fmt.Errorf("%s rejected the login state, please login with --validate-state=false", product.DisplayName())
+	}
+
+	keyChannel := make(chan string, 1)
+	server := &http.Server{
+		Handler:           loginCallbackHandler(state, fmt.Sprintf(RedirectPath, host), keyChannel, log),
+		ReadHeaderTimeout: time.Second * 10,
+	}
+	for _, listener := range listeners {
+		go func(listener net.Listener) {
+			// cannot panic, because this probably is an intentional close
+			_ = server.Serve(listener)
+		}(listener)
+	}
+	defer func() {
+		err := server.Shutdown(context.Background())
+		if err != nil {
+			log.Debugf("Error shutting down server: %v", err)
+		}
+	}()
+
+	err = open.Run(loginURL)
+	if err != nil {
+		log.Infof("Couldn't open the login page in a browser: %v", err)
+		return headlessLogin(ctx, httpClient, host, log)
+	}
+
+	log.Infof("If the browser does not open automatically, please navigate to %s", loginURL)
+	msg := "If you have problems logging in, please navigate to %s/profile/access-keys, click on 'Create Access Key' and then login via '%s %s --access-key ACCESS_KEY"
+	if insecure {
+		msg += " --insecure"
+	}
+	msg += "'"
+	log.Infof(msg, host, product.LoginCmd(), host)
+	log.Infof("Logging into %s...", product.DisplayName())
+
+	select {
+	case key := <-keyChannel:
+		return key, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(loginTimeout):
+		return "", fmt.Errorf("timed out after %s waiting for the login to complete. Please try again or login via '%s %s --access-key ACCESS_KEY'", loginTimeout, product.LoginCmd(), host)
+	}
+}
+
+// buildLoginURL returns the url of the login page that redirects to the callback server on
+// the given port. The default port is not sent, so it works with every loft version.
+func buildLoginURL(host string, port int, state string) string {
+	query := url.Values{}
+	if port != DefaultCallbackPort {
+		query.Set("port", fmt.Sprint(port))
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+
+	loginURL := fmt.Sprintf(LoginPath, host)
+	if len(query) > 0 {
+		loginURL += "&" + query.Encode()
+	}
+
+	return loginURL
+}
+
+// loginURLRejected returns true if loft answers the login url with a client error, which
+// means it doesn't accept its query parameters. If loft can't be reached, the url is
+// assumed to be fine and the browser shows the actual error.
+func loginURLRejected(ctx context.Context, httpClient *http.Client, loginURL string, log log.Logger) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loginURL, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		log.Debugf("Error checking the login url: %v", err)
+		return false
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnprocessableEntity
+}
+
+// listenLoopback listens on the IPv4 and IPv6 loopback address, because the login page redirects
+// to localhost, which browsers may resolve to either. A missing IPv6 stack is not an error.
+func listenLoopback(port int, log log.Logger) ([]net.Listener, error) {
+	ipv4, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", fmt.Sprint(port)))
+	if err != nil {
+		return nil, err
+	}
+
+	// use the same port for IPv6 if a random one was chosen
+	ipv6, err := net.Listen("tcp", net.JoinHostPort("::1", fmt.Sprint(ipv4.Addr().(*net.TCPAddr).Port)))
+	if err != nil {
+		log.Debugf("Error listening on the IPv6 loopback address: %v", err)
+		return []net.Listener{ipv4}, nil
+	}
+
+	return []net.Listener{ipv4, ipv6}, nil
+}
+
+func closeListeners(listeners []net.Listener) {
+	for _, listener := range listeners {
+		_ = listener.Close()
+	}
+}
+
+// loginCallbackHandler receives the access key from the login page. If a state is
+// given, requests without it are rejected, so other local processes or websites
+// can't inject an access key.
+func loginCallbackHandler(state, redirectURI string, keyChannel chan<- string, log log.Logger) http.Handler {
+	once := sync.Once{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if state != "" && subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			log.Warnf("Login: ignoring callback with an invalid state. If your %s version doesn't return the state, please login with --validate-state=false", product.DisplayName())
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+
+		key := query.Get("key")
+		if key == "" {
+			log.Warn("Login: the key used to login is not valid")
+			http.Error(w, "invalid key", http.StatusBadRequest)
+			return
+		}
+
+		once.Do(func() {
+			keyChannel <- key
+		})
+		http.Redirect(w, r, redirectURI, http.StatusSeeOther)
+	})
+
+	return mux
+}
+
+// headlessLogin prints a url and a user code and polls loft until the login was
+// completed on another device. Loft instances without the device login only
+// support pasting an access key created in the UI.
+func headlessLogin(ctx context.Context, httpClient *http.Client, host string, log log.Logger) (string, error) {
+	accessKey, err := deviceLogin(ctx, httpClient, host, log)
+	if !errors.Is(err, errDeviceLoginUnsupported) {
+		return accessKey, err
+	}
+
+	log.Infof("%s doesn't support the device login", product.DisplayName())
+	log.Infof("Please navigate to %s, click on 'Create Access Key' and paste the access key below", fmt.Sprintf(AccessKeyPath, host))
+	accessKey, err = log.Question(&survey.QuestionOptions{
+		Question:   "Access Key",
+		IsPassword: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	accessKey = strings.TrimSpace(accessKey)
+	if accessKey == "" {
+		return "", fmt.Errorf("no access key provided")
+	}
+
+	return accessKey, nil
+}
+
+// deviceLogin requests a device code and polls the token endpoint until the user confirmed
+// the code, the code expired or the context is done
+func deviceLogin(ctx context.Context, httpClient *http.Client, host string, log log.Logger) (string, error) {
+	deviceCode := &deviceCodeResponse{}
+	status, err := postJSON(ctx, httpClient, fmt.Sprintf(DevicePath, host), nil, deviceCode)
+	if err != nil {
+		return "", perrors.Wrap(err, "request device code")
+	} else if status == http.StatusNotFound || status == http.StatusMethodNotAllowed {
+		return "", errDeviceLoginUnsupported
+	} else if status != http.StatusOK {
+		return "", fmt.Errorf("request device code: unexpected status code %d", status)
+	}
+
+	if deviceCode.VerificationURIComplete != "" {
+		log.Infof("To login, please open %s and confirm the code %s", deviceCode.VerificationURIComplete, deviceCode.UserCode)
+	} else {
+		log.Infof("To login, please open %s and enter the code %s", deviceCode.VerificationURI, deviceCode.UserCode)
+	}
+	log.Infof("Waiting for the login to complete...")
+
+	interval := time.Duration(deviceCode.Interval) * time.Second
+	if interval <= 0 {
+		interval = time.Second * 5
+	}
+	expiresIn := time.Duration(deviceCode.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = deviceLoginTimeout
+	}
+	deadline := time.Now().Add(expiresIn)
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(interval):
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("the device code expired, please try to login again")
+		}
+
+		token := &deviceTokenResponse{}
+		_, err := postJSON(ctx, httpClient, fmt.Sprintf(DeviceTokenPath, host), map[string]string{"deviceCode": deviceCode.DeviceCode}, token)
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+
+			return "", perrors.Wrap(err, "poll device login")
+		}
+
+		switch token.Error {
+		case "":
+			if token.AccessKey == "" {
+				return "", fmt.Errorf("poll device login: no access key returned")
+			}
+
+			return token.AccessKey, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownInterval
+		case "access_denied":
+			return "", fmt.Errorf("the login was denied")
+		case "expired_token":
+			return "", fmt.Errorf("the device code expired, please try to login again")
+		default:
+			return "", fmt.Errorf("poll device login: %s", token.Error)
+		}
+	}
+}
+
+// postJSON posts the body to the url and decodes the response into out if possible
+func postJSON(ctx context.Context, httpClient *http.Client, target string, body, out interface{}) (int, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(raw))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// errors of the device login are returned as json with a non 200 status code
+	if resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusMethodNotAllowed {
+		_ = json.NewDecoder(resp.Body).Decode(out)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func newState() (string, error) {
+	state := make([]byte, 16)
+	_, err := rand.Read(state)
+	if err != nil {
+		return "", perrors.Wrap(err, "generate state")
+	}
+
+	return hex.EncodeToString(state), nil
+}
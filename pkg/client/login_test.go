@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/log"
+	"gotest.tools/v3/assert"
+)
+
+func TestLoginCallbackHandler(t *testing.T) {
+	keyChannel := make(chan string, 1)
+	handler := loginCallbackHandler("my-state", "https://my-loft.example.com/spaces", keyChannel, log.Discard)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/login?key=injected&state=other", nil))
+	assert.Equal(t, recorder.Code, http.StatusBadRequest)
+	assert.Equal(t, len(keyChannel), 0)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/login?key=my-key&state=my-state", nil))
+	assert.Equal(t, recorder.Code, http.StatusSeeOther)
+	assert.Equal(t, <-keyChannel, "my-key")
+}
+
+func TestLoginCallbackHandlerWithoutState(t *testing.T) {
+	keyChannel := make(chan string, 1)
+	handler := loginCallbackHandler("", "https://my-loft.example.com/spaces", keyChannel, log.Discard)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/login", nil))
+	assert.Equal(t, recorder.Code, http.StatusBadRequest)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/login?key=my-key", nil))
+	assert.Equal(t, recorder.Code, http.StatusSeeOther)
+	assert.Equal(t, <-keyChannel, "my-key")
+}
+
+func TestLoginURLRejected(t *testing.T) {
+	// the instance only knows the state parameter
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("port") {
+			http.Error(w, "unknown parameter port", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	loginURL := buildLoginURL(server.URL, 1234, "my-state")
+	assert.Assert(t, strings.Contains(loginURL, "port=1234"))
+	assert.Assert(t, loginURLRejected(context.Background(), server.Client(), loginURL, log.Discard))
+
+	loginURL = buildLoginURL(server.URL, DefaultCallbackPort, "my-state")
+	assert.Assert(t, !strings.Contains(loginURL, "port="))
+	assert.Assert(t, !loginURLRejected(context.Background(), server.Client(), loginURL, log.Discard))
+}
+
+func TestDeviceLogin(t *testing.T) {
+	defer func(interval time.Duration) { slowDownInterval = interval }(slowDownInterval)
+	slowDownInterval = time.Millisecond
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/device":
+			_ = json.NewEncoder(w).Encode(&deviceCodeResponse{DeviceCode: "device", UserCode: "ABCD-EFGH", VerificationURI: "https://my-loft.example.com/device", ExpiresIn: 60, Interval: 1})
+		case "/auth/device/token":
+			request := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			assert.Check(t, request["deviceCode"] == "device")
+
+			polls++
+			switch polls {
+			case 1:
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&deviceTokenResponse{Error: "authorization_pending"})
+			case 2:
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&deviceTokenResponse{Error: "slow_down"})
+			default:
+				_ = json.NewEncoder(w).Encode(&deviceTokenResponse{AccessKey: "my-key"})
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	accessKey, err := deviceLogin(context.Background(), server.Client(), server.URL, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, accessKey, "my-key")
+	assert.Equal(t, polls, 3)
+
+	_, err = deviceLogin(context.Background(), server.Client(), server.URL+"/unsupported", log.Discard)
+	assert.ErrorIs(t, err, errDeviceLoginUnsupported)
+}
+
+func TestDeviceLoginExpired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/device" {
+			_ = json.NewEncoder(w).Encode(&deviceCodeResponse{DeviceCode: "device", UserCode: "ABCD-EFGH", VerificationURI: "https://my-loft.example.com/device", ExpiresIn: 1, Interval: 1})
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(&deviceTokenResponse{Error: "authorization_pending"})
+	}))
+	defer server.Close()
+
+	_, err := deviceLogin(context.Background(), server.Client(), server.URL, log.Discard)
+	assert.ErrorContains(t, err, "expired")
+}
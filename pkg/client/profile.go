@@ -0,0 +1,291 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultProfile is the profile logins are stored in if no profile was specified
+const DefaultProfile = "default"
+
+// profileNameRegEx matches valid profile names. Names are part of file names and credential
+// keys, so they must not contain path separators.
+var profileNameRegEx = regexp.MustCompile(`^[a-z0-9]([-a-z0-9_]*[a-z0-9])?$`)
+
+// ValidateProfileName returns an error if the name can't be used as a profile name
+func ValidateProfileName(name string) error {
+	if len(name) > 63 || !profileNameRegEx.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: profile names can have at most 63 characters, may only contain lowercase letters, digits, '-' and '_' and must start and end with a letter or digit", name)
+	}
+
+	return nil
+}
+
+// profileOverride is the profile selected via --profile, it takes
+// precedence over the current profile of the config file
+var profileOverride string
+
+// SetProfile selects the profile all clients created from a config path use
+// instead of the current profile of the config file
+func SetProfile(name string) {
+	profileOverride = name
+}
+
+// ActiveProfile returns the profile that is used for the config at the given path
+func ActiveProfile(path string) (string, error) {
+	if profileOverride != "" {
+		return profileOverride, nil
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		return "", err
+	}
+
+	return config.CurrentProfile, nil
+}
+
+// SwitchProfile makes the given profile the current profile of the config at the given path
+func SwitchProfile(path, name string) error {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	err = config.UseProfile(name)
+	if err != nil {
+		return err
+	}
+
+	return SaveConfig(path, config)
+}
+
+// Profile holds the login information for a single loft instance
+type Profile struct {
+	// host is the http endpoint of how to access loft
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// insecure specifies if the loft instance is insecure
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// CertificateAuthority is the path to a PEM encoded certificate authority bundle to verify the loft host with
+	// +optional
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+
+	// CertificateAuthorityData is a PEM encoded certificate authority bundle to verify the loft host with
+	// +optional
+	CertificateAuthorityData []byte `json:"certificateAuthorityData,omitempty"`
+
+	// access key is the access key for the given loft host
+	// +optional
+	AccessKey string `json:"accesskey,omitempty"`
+
+	// virtual cluster access key is the access key for the given loft host to create virtual clusters
+	// +optional
+	VirtualClusterAccessKey string `json:"virtualClusterAccessKey,omitempty"`
+
+	// the direct cluster endpoint token
+	// +optional
+	DirectClusterEndpointToken string `json:"directClusterEndpointToken,omitempty"`
+
+	// last time the direct cluster endpoint token was requested
+	// +optional
+	DirectClusterEndpointTokenRequested *metav1.Time `json:"directClusterEndpointTokenRequested,omitempty"`
+
+	// map of cached certificates for "access point" mode virtual clusters
+	// +optional
+	VirtualClusterAccessPointCertificates map[string]VirtualClusterCertificatesEntry `json:"virtualClusterAccessPointCertificates,omitempty"`
+}
+
+// LoadConfig reads the config at the given path. A new config is returned if the file does not exist.
+func LoadConfig(path string) (*Config, error) {
+	config, err := readConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadCredentials(path, config)
+	if err != nil {
+		return nil, fmt.Errorf("load credentials: %w", err)
+	}
+
+	return config, nil
+}
+
+// readConfig parses the config at the given path without resolving its credential references
+func readConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewConfig(), nil
+		}
+
+		return nil, err
+	}
+
+	config := &Config{
+		VirtualClusterAccessPointCertificates: make(map[string]VirtualClusterCertificatesEntry),
+	}
+	err = json.Unmarshal(content, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// configs written before profiles existed only hold a single login
+	if len(config.Profiles) == 0 && config.Host != "" {
+		config.CurrentProfile = DefaultProfile
+		config.Profiles = map[string]*Profile{DefaultProfile: config.profile()}
+	}
+
+	config.loadedProfiles = profileSet(config)
+	return config, nil
+}
+
+// SaveConfig writes the config to the given path. Secrets are written to the
+// credential store of the config and only referenced in the file. Concurrent
+// writes from other processes are serialized through a lock file.
+func SaveConfig(path string, config *Config) error {
+	if config.Kind == "" {
+		config.Kind = "Config"
+	}
+	if config.APIVersion == "" {
+		config.APIVersion = "storage.loft.sh/v1"
+	}
+
+	unlock, err := lockConfig(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// other processes might have added profiles or cached certificates since the config was
+	// loaded. The config on disk is read without its credentials, only merged values are resolved.
+	disk, err := readConfig(path)
+	if err == nil {
+		err = mergeProfiles(path, config, disk)
+		if err != nil {
+			return fmt.Errorf("merge profiles: %w", err)
+		}
+
+		err = mergeCertificates(path, config, disk)
+		if err != nil {
+			return fmt.Errorf("merge certificates: %w", err)
+		}
+	}
+
+	stored, storedCredentials, err := storeCredentials(path, config)
+	if err != nil {
+		return fmt.Errorf("store credentials: %w", err)
+	}
+
+	out, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(path, out, 0o660)
+	if err != nil {
+		return err
+	}
+
+	config.credentialBackend = config.CredentialStore
+	config.storedCredentials = storedCredentials
+	config.loadedProfiles = profileSet(config)
+	return nil
+}
+
+// ProfileNames returns the sorted names of all profiles
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// UseProfile makes the given profile the current profile
+func (c *Config) UseProfile(name string) error {
+	err := ValidateProfileName(name)
+	if err != nil {
+		return err
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return fmt.Errorf("profile %s does not exist", name)
+	}
+
+	c.CurrentProfile = name
+	c.applyProfile(profile)
+	return nil
+}
+
+// DeleteProfile removes the given profile. If it is the current profile, the config is logged out.
+func (c *Config) DeleteProfile(name string) error {
+	err := ValidateProfileName(name)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := c.Profiles[name]; !ok {
+		return fmt.Errorf("profile %s does not exist", name)
+	}
+
+	delete(c.Profiles, name)
+	if c.CurrentProfile == name {
+		c.CurrentProfile = ""
+		c.applyProfile(&Profile{})
+	}
+
+	return nil
+}
+
+// selectProfile loads the given profile into the config without changing
+// the current profile on disk
+func (c *Config) selectProfile(name string) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		profile = &Profile{}
+	}
+
+	c.CurrentProfile = name
+	c.applyProfile(profile)
+}
+
+func (c *Config) profile() *Profile {
+	return &Profile{
+		Host:                                  c.Host,
+		Insecure:                              c.Insecure,
+		CertificateAuthority:                  c.CertificateAuthority,
+		CertificateAuthorityData:              c.CertificateAuthorityData,
+		AccessKey:                             c.AccessKey,
+		VirtualClusterAccessKey:               c.VirtualClusterAccessKey,
+		DirectClusterEndpointToken:            c.DirectClusterEndpointToken,
+		DirectClusterEndpointTokenRequested:   c.DirectClusterEndpointTokenRequested,
+		VirtualClusterAccessPointCertificates: c.VirtualClusterAccessPointCertificates,
+	}
+}
+
+func (c *Config) applyProfile(profile *Profile) {
+	c.Host = profile.Host
+	c.Insecure = profile.Insecure
+	c.CertificateAuthority = profile.CertificateAuthority
+	c.CertificateAuthorityData = profile.CertificateAuthorityData
+	c.AccessKey = profile.AccessKey
+	c.VirtualClusterAccessKey = profile.VirtualClusterAccessKey
+	c.DirectClusterEndpointToken = profile.DirectClusterEndpointToken
+	c.DirectClusterEndpointTokenRequested = profile.DirectClusterEndpointTokenRequested
+	c.VirtualClusterAccessPointCertificates = profile.VirtualClusterAccessPointCertificates
+	if c.VirtualClusterAccessPointCertificates == nil {
+		c.VirtualClusterAccessPointCertificates = make(map[string]VirtualClusterCertificatesEntry)
+	}
+}
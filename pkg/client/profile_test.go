@@ -0,0 +1,61 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(path, []byte(`{"host":"https://prod.example.com","accesskey":"prod"}`), 0o600)
+	assert.NilError(t, err)
+
+	// logging into another profile keeps the current one
+	SetProfile("staging")
+	defer SetProfile("")
+	c := &client{configPath: path}
+	assert.NilError(t, c.initConfig())
+	assert.Equal(t, c.config.Host, "")
+	c.config.Host = "https://staging.example.com"
+	c.config.AccessKey = "staging"
+	assert.NilError(t, c.Save())
+
+	config, err := LoadConfig(path)
+	assert.NilError(t, err)
+	assert.Equal(t, config.CurrentProfile, DefaultProfile)
+	assert.Equal(t, config.Host, "https://prod.example.com")
+	assert.DeepEqual(t, config.ProfileNames(), []string{DefaultProfile, "staging"})
+
+	// switching updates the top level login
+	assert.NilError(t, SwitchProfile(path, "staging"))
+	SetProfile("")
+	c = &client{configPath: path}
+	assert.NilError(t, c.initConfig())
+	assert.Equal(t, c.config.AccessKey, "staging")
+
+	assert.NilError(t, c.config.DeleteProfile("staging"))
+	assert.Equal(t, c.config.Host, "")
+	assert.ErrorContains(t, c.config.UseProfile("staging"), "does not exist")
+}
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"default", "staging", "prod-eu_1", "a"} {
+		assert.NilError(t, ValidateProfileName(name), name)
+	}
+	for _, name := range []string{"", "../../x", "a/b", `a\b`, "..", ".hidden", "Prod", "-a", "a-", "a b", strings.Repeat("a", 64)} {
+		assert.ErrorContains(t, ValidateProfileName(name), "invalid profile name", name)
+	}
+
+	config := NewConfig()
+	config.Profiles = map[string]*Profile{DefaultProfile: {}}
+	assert.ErrorContains(t, config.UseProfile("../../x"), "invalid profile name")
+	assert.ErrorContains(t, config.DeleteProfile("a/b"), "invalid profile name")
+	assert.Equal(t, len(config.Profiles), 1)
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.ErrorContains(t, SwitchProfile(path, "../x"), "invalid profile name")
+}
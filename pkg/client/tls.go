@@ -0,0 +1,67 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSOptions configure how the certificate of the loft host is verified
+type TLSOptions struct {
+	// Insecure skips the verification if no certificate authority is configured
+	Insecure bool
+
+	// CertificateAuthority is the path to a PEM encoded certificate authority bundle
+	CertificateAuthority string
+
+	// CertificateAuthorityData is a PEM encoded certificate authority bundle
+	CertificateAuthorityData []byte
+}
+
+// TLSOptions returns the tls options of the config
+func (c *Config) TLSOptions() TLSOptions {
+	return TLSOptions{
+		Insecure:                 c.Insecure,
+		CertificateAuthority:     c.CertificateAuthority,
+		CertificateAuthorityData: c.CertificateAuthorityData,
+	}
+}
+
+// HasCertificateAuthority returns true if a custom certificate authority is configured
+func (o TLSOptions) HasCertificateAuthority() bool {
+	return o.CertificateAuthority != "" || len(o.CertificateAuthorityData) > 0
+}
+
+// CAData returns the configured certificate authority bundle or nil if the system roots should be used
+func (o TLSOptions) CAData() ([]byte, error) {
+	if len(o.CertificateAuthorityData) > 0 {
+		return o.CertificateAuthorityData, nil
+	} else if o.CertificateAuthority != "" {
+		data, err := os.ReadFile(o.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("read certificate authority: %w", err)
+		}
+
+		return data, nil
+	}
+
+	return nil, nil
+}
+
+// TLSConfig returns a tls config that verifies the loft host with the configured options
+func (o TLSOptions) TLSConfig() (*tls.Config, error) {
+	caData, err := o.CAData()
+	if err != nil {
+		return nil, err
+	} else if len(caData) == 0 {
+		return &tls.Config{InsecureSkipVerify: o.Insecure}, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caData) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in certificate authority")
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
+}
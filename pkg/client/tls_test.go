@@ -0,0 +1,52 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTLSOptions(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "internal-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NilError(t, err)
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	assert.NilError(t, os.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	// a certificate authority takes precedence over insecure
+	tlsConfig, err := TLSOptions{Insecure: true, CertificateAuthority: caPath}.TLSConfig()
+	assert.NilError(t, err)
+	assert.Assert(t, !tlsConfig.InsecureSkipVerify)
+	assert.Assert(t, tlsConfig.RootCAs != nil)
+
+	tlsConfig, err = TLSOptions{Insecure: true}.TLSConfig()
+	assert.NilError(t, err)
+	assert.Assert(t, tlsConfig.InsecureSkipVerify)
+
+	_, err = TLSOptions{CertificateAuthorityData: []byte("invalid")}.TLSConfig()
+	assert.ErrorContains(t, err, "no PEM encoded certificates")
+
+	restConfig, err := GetRestConfigWithTLS("https://my-loft.example.com", "token", TLSOptions{Insecure: true, CertificateAuthority: caPath})
+	assert.NilError(t, err)
+	assert.Assert(t, !restConfig.Insecure)
+	assert.Equal(t, restConfig.CAFile, caPath)
+}
@@ -0,0 +1,16 @@
+package client
+
+import "github.com/loft-sh/loftctl/v4/pkg/httputil"
+
+// tracer traces the requests of all rest configs created via GetRestConfig
+var tracer *httputil.Tracer
+
+// SetTracer enables tracing of all management api requests. A nil tracer disables tracing.
+func SetTracer(t *httputil.Tracer) {
+	tracer = t
+}
+
+// Tracer returns the tracer set via SetTracer, nil if tracing is disabled
+func Tracer() *httputil.Tracer {
+	return tracer
+}
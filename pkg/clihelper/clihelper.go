@@ -3,6 +3,7 @@ package clihelper
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -271,10 +272,17 @@ type version struct {
 	Version string `json:"version"`
 }
 
-func IsLoftReachable(ctx context.Context, host string) (bool, error) {
+func IsLoftReachable(ctx context.Context, host string, tlsConfig *tls.Config) (bool, error) {
+	// fresh installations use a self-signed certificate, so only verify it
+	// if a tls config was given
+	transport := httputil.InsecureTransport()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
 	// wait until loft is reachable at the given url
 	client := &http.Client{
-		Transport: httputil.InsecureTransport(),
+		Transport: transport,
 	}
 	url := "https://" + host + "/version"
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
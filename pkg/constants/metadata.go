@@ -9,4 +9,7 @@ const (
 	LoftDefaultSpaceTemplate = "space.loft.sh/default-template"
 
 	LoftCacheFolderEnv = "LOFT_CACHE_FOLDER"
+
+	// LoftMaxRetriesEnv overrides how often failed management api requests are retried
+	LoftMaxRetriesEnv = "LOFT_MAX_RETRIES"
 )
@@ -0,0 +1,216 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const fileVersion = 1
+
+type encryptedFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Data    []byte `json:"data"`
+}
+
+// FileStore stores credentials in a file that is encrypted with a key derived
+// from a passphrase
+type FileStore struct {
+	Path       string
+	Passphrase string
+
+	salt   []byte
+	key    []byte
+	values map[string]string
+}
+
+// NewFileStore creates a new encrypted file store at the given path
+func NewFileStore(path, passphrase string) *FileStore {
+	return &FileStore{
+		Path:       path,
+		Passphrase: passphrase,
+	}
+}
+
+// Get returns the credential with the given key
+func (s *FileStore) Get(key string) (string, error) {
+	err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := s.values[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+// Set stores the credential with the given key
+func (s *FileStore) Set(key, value string) error {
+	err := s.load()
+	if err != nil {
+		return err
+	} else if current, ok := s.values[key]; ok && current == value {
+		return nil
+	}
+
+	s.values[key] = value
+	return s.save()
+}
+
+// Delete removes the credential with the given key
+func (s *FileStore) Delete(key string) error {
+	err := s.load()
+	if err != nil {
+		return err
+	} else if _, ok := s.values[key]; !ok {
+		return ErrNotFound
+	}
+
+	delete(s.values, key)
+	return s.save()
+}
+
+func (s *FileStore) load() error {
+	if s.values != nil {
+		return nil
+	}
+
+	content, err := os.ReadFile(s.Path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		s.salt = make([]byte, 16)
+		_, err = io.ReadFull(rand.Reader, s.salt)
+		if err != nil {
+			return fmt.Errorf("generate salt: %w", err)
+		}
+
+		s.key, err = deriveKey(s.Passphrase, s.salt)
+		if err != nil {
+			return err
+		}
+
+		s.values = map[string]string{}
+		return nil
+	}
+
+	file := &encryptedFile{}
+	err = json.Unmarshal(content, file)
+	if err != nil {
+		return fmt.Errorf("parse credentials file %s: %w", s.Path, err)
+	} else if file.Version != fileVersion {
+		return fmt.Errorf("unsupported credentials file version %d", file.Version)
+	}
+
+	key, err := deriveKey(s.Passphrase, file.Salt)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	} else if len(file.Data) < gcm.NonceSize() {
+		return errors.New("credentials file is corrupted")
+	}
+
+	plaintext, err := gcm.Open(nil, file.Data[:gcm.NonceSize()], file.Data[gcm.NonceSize():], nil)
+	if err != nil {
+		return fmt.Errorf("decrypt credentials file %s, is the passphrase correct?", s.Path)
+	}
+
+	values := map[string]string{}
+	err = json.Unmarshal(plaintext, &values)
+	if err != nil {
+		return fmt.Errorf("parse credentials: %w", err)
+	}
+
+	s.salt = file.Salt
+	s.key = key
+	s.values = values
+	return nil
+}
+
+func (s *FileStore) save() error {
+	plaintext, err := json.Marshal(s.values)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(s.key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+
+	out, err := json.Marshal(&encryptedFile{
+		Version: fileVersion,
+		Salt:    s.salt,
+		Data:    gcm.Seal(nonce, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(s.Path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// replace the file atomically, so a crash never leaves a truncated file behind
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.Path), "."+filepath.Base(s.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(out)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), s.Path)
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive key from passphrase: %w", err)
+	}
+
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,97 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// KeyringStore stores credentials in the OS keyring through the Secret Service
+// D-Bus API. It uses secret-tool from libsecret to talk to the keyring.
+type KeyringStore struct {
+	Service string
+	Config  string
+
+	run func(stdin string, args ...string) (string, error)
+}
+
+// NewKeyringStore creates a keyring store whose items are scoped to the given service and config
+func NewKeyringStore(service, config string) *KeyringStore {
+	return &KeyringStore{
+		Service: service,
+		Config:  config,
+		run:     runSecretTool,
+	}
+}
+
+// Get returns the credential with the given key
+func (s *KeyringStore) Get(key string) (string, error) {
+	out, err := s.run("", append([]string{"lookup"}, s.attributes(key)...)...)
+	if err != nil {
+		// secret-tool exits without output if the item does not exist
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && out == "" {
+			return "", ErrNotFound
+		}
+
+		return "", fmt.Errorf("lookup %s in keyring: %w", key, err)
+	}
+
+	return out, nil
+}
+
+// Set stores the credential with the given key
+func (s *KeyringStore) Set(key, value string) error {
+	args := append([]string{"store", "--label", s.Service + " " + key}, s.attributes(key)...)
+	_, err := s.run(value, args...)
+	if err != nil {
+		return fmt.Errorf("store %s in keyring: %w", key, err)
+	}
+
+	return nil
+}
+
+// Delete removes the credential with the given key
+func (s *KeyringStore) Delete(key string) error {
+	_, err := s.run("", append([]string{"clear"}, s.attributes(key)...)...)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return ErrNotFound
+		}
+
+		return fmt.Errorf("delete %s from keyring: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *KeyringStore) attributes(key string) []string {
+	return []string{"service", s.Service, "config", s.Config, "key", key}
+}
+
+func runSecretTool(stdin string, args ...string) (string, error) {
+	path, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return "", fmt.Errorf("the %s credential store requires secret-tool (libsecret) to access the Secret Service API: %w", BackendKeyring, err)
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	err = cmd.Run()
+	if err != nil {
+		if stderr.Len() > 0 {
+			return stdout.String(), fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+
+		return stdout.String(), err
+	}
+
+	return stdout.String(), nil
+}
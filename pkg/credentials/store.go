@@ -0,0 +1,103 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+const (
+	// BackendPlaintext keeps the secrets within the cli config
+	BackendPlaintext = "plaintext"
+	// BackendKeyring stores the secrets in the OS keyring via the Secret Service API, which is
+	// only available on linux and the BSDs
+	BackendKeyring = "keyring"
+	// BackendFile stores the secrets in a passphrase encrypted file next to the cli config
+	BackendFile = "file"
+
+	// PassphraseEnv holds the passphrase of the encrypted file backend
+	PassphraseEnv = "LOFT_CREDENTIALS_PASSPHRASE"
+
+	keyringService = "loft"
+)
+
+// Backends are all supported credential store backends
+var Backends = []string{BackendPlaintext, BackendKeyring, BackendFile}
+
+// keyringPlatforms are the platforms that provide the Secret Service API through secret-tool
+var keyringPlatforms = []string{"linux", "freebsd", "openbsd", "netbsd", "dragonfly"}
+
+// ErrNotFound is returned if a credential does not exist in the store
+var ErrNotFound = errors.New("credential not found")
+
+// Store persists the secrets of the cli config outside of it
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// IsPlaintext returns true if the backend keeps the secrets within the cli config
+func IsPlaintext(backend string) bool {
+	return backend == "" || backend == BackendPlaintext
+}
+
+// Validate returns an error if the backend is unknown or not supported on this platform
+func Validate(backend string) error {
+	return validate(backend, runtime.GOOS)
+}
+
+func validate(backend, goos string) error {
+	switch backend {
+	case "", BackendPlaintext, BackendFile:
+		return nil
+	case BackendKeyring:
+		if !slices.Contains(keyringPlatforms, goos) {
+			return fmt.Errorf("the %s credential store is not supported on %s, because it requires the Secret Service API. Please use the %s credential store instead", BackendKeyring, goos, BackendFile)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown credential store %s, needs to be one of: %s", backend, strings.Join(Backends, ", "))
+	}
+}
+
+// NewStore creates the store of the given backend for the cli config at configPath.
+// The plaintext backend has no store, so nil is returned for it.
+func NewStore(backend, configPath string) (Store, error) {
+	err := Validate(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	absConfigPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend {
+	case "", BackendPlaintext:
+		return nil, nil
+	case BackendKeyring:
+		return NewKeyringStore(keyringService, absConfigPath), nil
+	case BackendFile:
+		passphrase := os.Getenv(PassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("the %s credential store requires a passphrase, please set the %s environment variable", BackendFile, PassphraseEnv)
+		}
+
+		return NewFileStore(FilePath(absConfigPath), passphrase), nil
+	default:
+		return nil, fmt.Errorf("unknown credential store %s", backend)
+	}
+}
+
+// FilePath returns the path of the encrypted credentials file of the given cli config
+func FilePath(configPath string) string {
+	ext := filepath.Ext(configPath)
+	return strings.TrimSuffix(configPath, ext) + ".credentials" + ext
+}
@@ -0,0 +1,70 @@
+package credentials
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.credentials.json")
+	store := NewFileStore(path, "my-passphrase")
+	assert.NilError(t, store.Set("default/accesskey", "secret"))
+	assert.NilError(t, store.Set("staging/accesskey", "other"))
+	assert.NilError(t, store.Delete("staging/accesskey"))
+
+	value, err := NewFileStore(path, "my-passphrase").Get("default/accesskey")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "secret")
+
+	_, err = NewFileStore(path, "my-passphrase").Get("staging/accesskey")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = NewFileStore(path, "wrong").Get("default/accesskey")
+	assert.ErrorContains(t, err, "is the passphrase correct")
+}
+
+func TestKeyringStore(t *testing.T) {
+	items := map[string]string{}
+	store := NewKeyringStore("loft", "/home/user/.loft/config.json")
+	store.run = func(stdin string, args ...string) (string, error) {
+		item := strings.Join(args[len(args)-6:], " ")
+		switch args[0] {
+		case "store":
+			items[item] = stdin
+		case "lookup":
+			value, ok := items[item]
+			if !ok {
+				return "", &exec.ExitError{}
+			}
+			return value, nil
+		case "clear":
+			delete(items, item)
+		}
+
+		return "", nil
+	}
+
+	assert.NilError(t, store.Set("default/accesskey", "secret"))
+	assert.DeepEqual(t, items, map[string]string{"service loft config /home/user/.loft/config.json key default/accesskey": "secret"})
+
+	value, err := store.Get("default/accesskey")
+	assert.NilError(t, err)
+	assert.Equal(t, value, "secret")
+
+	assert.NilError(t, store.Delete("default/accesskey"))
+	_, err = store.Get("default/accesskey")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NilError(t, validate(BackendKeyring, "linux"))
+	assert.NilError(t, validate(BackendFile, "darwin"))
+	assert.NilError(t, validate("", "windows"))
+	assert.ErrorContains(t, validate(BackendKeyring, "darwin"), "not supported on darwin")
+	assert.ErrorContains(t, validate(BackendKeyring, "windows"), "not supported on windows")
+	assert.ErrorContains(t, validate("vault", "linux"), "unknown credential store vault")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/pkg/errors"
@@ -31,25 +32,55 @@ type Defaults struct {
 
 // NewFromPath creates a new defaults instance from the given path
 func NewFromPath(folderPath string, fileName string) (*Defaults, error) {
-	fullPath := filepath.Join(folderPath, fileName)
-	defaults := &Defaults{folderPath, fileName, fullPath, make(map[string]string)}
+	defaults := &Defaults{folderPath: folderPath}
+	return defaults, defaults.load(fileName)
+}
+
+// SetProfile switches to the defaults of the given login profile
+func (d *Defaults) SetProfile(profile string) error {
+	if profile != "" {
+		err := client.ValidateProfileName(profile)
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.load(ProfileFile(profile))
+}
+
+// ProfileFile returns the defaults file name of the given login profile. The
+// default profile uses the regular defaults file. The profile name needs to be
+// valid, see client.ValidateProfileName.
+func ProfileFile(profile string) string {
+	if profile == "" || profile == client.DefaultProfile {
+		return ConfigFile
+	}
+
+	ext := filepath.Ext(ConfigFile)
+	return strings.TrimSuffix(ConfigFile, ext) + "." + profile + ext
+}
+
+func (d *Defaults) load(fileName string) error {
+	d.fileName = fileName
+	d.fullPath = filepath.Join(d.folderPath, fileName)
+	d.values = make(map[string]string)
 
-	if err := defaults.ensureConfigFile(); err != nil {
-		return defaults, errors.Wrap(err, "no config file")
+	if err := d.ensureConfigFile(); err != nil {
+		return errors.Wrap(err, "no config file")
 	}
 
-	contents, err := os.ReadFile(fullPath)
+	contents, err := os.ReadFile(d.fullPath)
 	if err != nil {
-		return defaults, errors.Wrap(err, "read config file")
+		return errors.Wrap(err, "read config file")
 	}
 	if len(contents) == 0 {
-		return defaults, nil
+		return nil
 	}
-	if err = json.Unmarshal(contents, &defaults.values); err != nil {
-		return defaults, errors.Wrap(err, "invalid json")
+	if err = json.Unmarshal(contents, &d.values); err != nil {
+		return errors.Wrap(err, "invalid json")
 	}
 
-	return defaults, nil
+	return nil
 }
 
 // Set sets the given key to the given value and persists the defaults on disk
@@ -17,6 +17,7 @@ import (
 	"github.com/loft-sh/loftctl/v4/pkg/client"
 	"github.com/loft-sh/loftctl/v4/pkg/projectutil"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
 )
 
 var (
@@ -128,15 +129,26 @@ func DialWorkspace(baseClient client.Client, workspace *managementv1.DevPodWorks
 		loftURL += "?" + values.Encode()
 	}
 
+	// verify loft with the same tls settings as the management client
+	tlsConfig, err := rest.TLSConfigFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create tls config: %w", err)
+	} else if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+
 	dialer := websocket.Dialer{
-		TLSClientConfig:  &tls.Config{InsecureSkipVerify: true},
+		TLSClientConfig:  tlsConfig,
 		Proxy:            http.ProxyFromEnvironment,
 		HandshakeTimeout: 45 * time.Second,
 	}
 
-	conn, response, err := dialer.Dial(loftURL, map[string][]string{
+	header := http.Header{
 		"Authorization": {"Bearer " + restConfig.BearerToken},
-	})
+	}
+	started := time.Now()
+	conn, response, err := dialer.Dial(loftURL, header)
+	traceHandshake(loftURL, header, response, started, err)
 	if err != nil {
 		if response != nil {
 			out, _ := io.ReadAll(response.Body)
@@ -149,3 +161,19 @@ func DialWorkspace(baseClient client.Client, workspace *managementv1.DevPodWorks
 
 	return conn, nil
 }
+
+// traceHandshake traces the websocket handshake, as the websocket dialer doesn't use the rest config transport
+func traceHandshake(loftURL string, header http.Header, response *http.Response, started time.Time, err error) {
+	tracer := client.Tracer()
+	if tracer == nil {
+		return
+	}
+
+	req, reqErr := http.NewRequest(http.MethodGet, loftURL, nil)
+	if reqErr != nil {
+		return
+	}
+
+	req.Header = header
+	tracer.Trace(req, response, started, err)
+}
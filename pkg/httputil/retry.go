@@ -0,0 +1,161 @@
+package httputil
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryOptions configure the retry transport
+type RetryOptions struct {
+	// MaxRetries is how often a request is retried at most. 0 disables retries.
+	MaxRetries int
+
+	// InitialBackoff is the backoff before the first retry, it doubles with every retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the backoff between retries
+	MaxBackoff time.Duration
+
+	// Logf is called for every retry
+	Logf func(format string, args ...interface{})
+}
+
+// DefaultRetryOptions retry for roughly 15 seconds, which covers a restart of the loft pod
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries:     5,
+		InitialBackoff: time.Millisecond * 500,
+		MaxBackoff:     time.Second * 8,
+	}
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent marks requests with the returned context as idempotent, so they
+// are retried on server errors regardless of their method
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// NewRetryTransport returns a round tripper that retries requests on connection errors
+// and idempotent requests on temporary server errors with jittered exponential backoff
+func NewRetryTransport(next http.RoundTripper, options RetryOptions) http.RoundTripper {
+	if options.MaxRetries <= 0 {
+		return next
+	}
+
+	return &retryTransport{
+		next:    next,
+		options: options,
+	}
+}
+
+type retryTransport struct {
+	next    http.RoundTripper
+	options RetryOptions
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.options.MaxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// the body was consumed by the previous attempt
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		backoff := t.backoff(attempt, resp)
+		if t.options.Logf != nil {
+			reason := ""
+			if err != nil {
+				reason = err.Error()
+			} else {
+				reason = resp.Status
+			}
+			t.options.Logf("Retrying %s %s in %s (%d/%d): %s", req.Method, req.URL.Redacted(), backoff.Round(time.Millisecond), attempt+1, t.options.MaxRetries, reason)
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the jittered exponential backoff for the given attempt. A
+// Retry-After header of the response takes precedence.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, t.options.MaxBackoff)
+		}
+	}
+
+	backoff := t.options.InitialBackoff << attempt
+	if backoff <= 0 || backoff > t.options.MaxBackoff {
+		backoff = t.options.MaxBackoff
+	}
+
+	// use between half and the full backoff, so parallel clients don't retry in lockstep
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// don't retry if the caller gave up
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || req.Context().Err() != nil {
+			return false
+		}
+
+		// requests that could not connect never reached the server
+		opErr := &net.OpError{}
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+
+		return isIdempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	}
+
+	return false
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	idempotent, _ := req.Context().Value(idempotentKey{}).(bool)
+	return idempotent
+}
@@ -0,0 +1,86 @@
+package httputil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRetryTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		if requests < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	retries := 0
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond * 10,
+		Logf: func(format string, args ...interface{}) {
+			retries++
+		},
+	})}
+
+	// idempotent requests are retried with their body
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("hello"))
+	assert.NilError(t, err)
+	resp, err := client.Do(req)
+	assert.NilError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	assert.Equal(t, string(body), "hello")
+	assert.Equal(t, requests, 3)
+	assert.Equal(t, retries, 2)
+
+	// other requests are only retried if marked as idempotent
+	requests = 0
+	resp, err = client.Post(server.URL, "text/plain", strings.NewReader("hello"))
+	assert.NilError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusBadGateway)
+	assert.Equal(t, requests, 1)
+
+	requests = 0
+	req, err = http.NewRequestWithContext(WithIdempotent(context.Background()), http.MethodPost, server.URL, strings.NewReader("hello"))
+	assert.NilError(t, err)
+	resp, err = client.Do(req)
+	assert.NilError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+}
+
+func TestRetryTransportConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	retries := 0
+	client := &http.Client{Transport: NewRetryTransport(http.DefaultTransport, RetryOptions{
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Logf: func(format string, args ...interface{}) {
+			retries++
+		},
+	})}
+
+	_, err := client.Post(url, "text/plain", strings.NewReader("hello"))
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Equal(t, retries, 2)
+}
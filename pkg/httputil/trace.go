@@ -0,0 +1,435 @@
+package httputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTraceBody is the maximum number of body bytes that are logged or recorded
+const maxTraceBody = 64 * 1024
+
+const redacted = "<redacted>"
+
+// sensitiveHeaders are never logged or recorded
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveKeyRegEx matches json keys and query parameters that hold secrets
+var sensitiveKeyRegEx = regexp.MustCompile(`(?i)token|accesskey|access_key|password|secret|privatekey|clientkey|keydata`)
+
+// sensitiveJSONRegEx matches json string fields whose key looks like a secret
+var sensitiveJSONRegEx = regexp.MustCompile(`("[^"]*(?i:token|accesskey|password|secret|privatekey|clientkey|keydata)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// sensitiveResources are the api resources and subresources whose bodies hold secrets in
+// fields that can't be recognized by their name, such as the base64 data of secrets, the key
+// of access keys or tokens within kubeconfigs. Their bodies are never logged or recorded.
+var sensitiveResources = []string{
+	"secrets",
+	"projectsecrets",
+	"sharedsecrets",
+	"accesskeys",
+	"ownedaccesskeys",
+	"accesskey",
+	"kubeconfig",
+	"virtualclusteraccesskey",
+	"auth",
+}
+
+// Tracer logs http requests and optionally records them in a HAR file
+type Tracer struct {
+	// Bodies enables logging and recording of request and response bodies
+	Bodies bool
+
+	// Logf is called for every request
+	Logf func(format string, args ...interface{})
+
+	// Version is the cli version that is written to the HAR file
+	Version string
+
+	// record enables recording of the requests for WriteHAR
+	record bool
+
+	m       sync.Mutex
+	entries []*harEntry
+}
+
+// NewTracer creates a new tracer. If record is true, all requests are kept in memory until
+// they are written via WriteHAR.
+func NewTracer(logf func(format string, args ...interface{}), bodies, record bool) *Tracer {
+	return &Tracer{
+		Bodies: bodies,
+		Logf:   logf,
+		record: record,
+	}
+}
+
+// Wrap returns a round tripper that traces all requests of the given round tripper.
+// A nil tracer returns the round tripper unchanged.
+func (t *Tracer) Wrap(next http.RoundTripper) http.RoundTripper {
+	if t == nil {
+		return next
+	}
+
+	return &traceTransport{
+		next:   next,
+		tracer: t,
+	}
+}
+
+type traceTransport struct {
+	next   http.RoundTripper
+	tracer *Tracer
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if t.tracer.Bodies && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		requestBody = body
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	started := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	entry := t.tracer.trace(req, requestBody, resp, started, err)
+	if err != nil || !t.tracer.Bodies || resp.StatusCode == http.StatusSwitchingProtocols {
+		return resp, err
+	}
+
+	// log the response body as soon as it was read, so streaming responses are not buffered
+	resp.Body = &traceBody{
+		ReadCloser: resp.Body,
+		tracer:     t.tracer,
+		entry:      entry,
+		request:    req,
+	}
+	return resp, nil
+}
+
+// Trace logs and records a single request. It's used for requests that don't go through
+// a traced round tripper, such as websocket handshakes. A nil tracer does nothing.
+func (t *Tracer) Trace(req *http.Request, resp *http.Response, started time.Time, err error) {
+	if t == nil {
+		return
+	}
+
+	t.trace(req, nil, resp, started, err)
+}
+
+func (t *Tracer) trace(req *http.Request, requestBody []byte, resp *http.Response, started time.Time, err error) *harEntry {
+	elapsed := time.Since(started)
+	if t.Logf != nil {
+		if err != nil {
+			t.Logf("%s %s failed after %s: %v", req.Method, redactURL(req.URL), elapsed.Round(time.Millisecond), err)
+		} else {
+			t.Logf("%s %s %s in %s", req.Method, redactURL(req.URL), resp.Status, elapsed.Round(time.Millisecond))
+		}
+		if len(requestBody) > 0 {
+			t.Logf("%s %s request body: %s", req.Method, redactURL(req.URL), truncateBody(req.URL, requestBody))
+		}
+	}
+	if !t.record {
+		return nil
+	}
+
+	entry := newHAREntry(req, requestBody, resp, started, elapsed)
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.entries = append(t.entries, entry)
+	return entry
+}
+
+// WriteHAR writes all recorded requests as HAR file to the given path
+func (t *Tracer) WriteHAR(path string) error {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	entries := t.entries
+	if entries == nil {
+		entries = []*harEntry{}
+	}
+
+	out, err := json.MarshalIndent(&har{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "loftctl", Version: t.Version},
+			Entries: entries,
+		},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, out, 0o600)
+}
+
+// traceBody captures the response body while it's read by the caller
+type traceBody struct {
+	io.ReadCloser
+
+	tracer  *Tracer
+	entry   *harEntry
+	request *http.Request
+
+	buffer bytes.Buffer
+	once   sync.Once
+}
+
+func (b *traceBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if remaining := maxTraceBody - b.buffer.Len(); remaining > 0 {
+		b.buffer.Write(p[:min(n, remaining)])
+	}
+	if err == io.EOF {
+		b.done()
+	}
+
+	return n, err
+}
+
+func (b *traceBody) Close() error {
+	b.done()
+	return b.ReadCloser.Close()
+}
+
+func (b *traceBody) done() {
+	b.once.Do(func() {
+		if b.buffer.Len() == 0 {
+			return
+		}
+
+		if b.tracer.Logf != nil {
+			b.tracer.Logf("%s %s response body: %s", b.request.Method, redactURL(b.request.URL), truncateBody(b.request.URL, b.buffer.Bytes()))
+		}
+		if b.entry != nil {
+			b.tracer.m.Lock()
+			defer b.tracer.m.Unlock()
+			b.entry.Response.Content.Size = b.buffer.Len()
+			b.entry.Response.Content.Text = redactBody(b.request.URL, b.buffer.Bytes())
+		}
+	})
+}
+
+func truncateBody(u *url.URL, body []byte) string {
+	if len(body) > maxTraceBody && !isSensitiveResource(u) {
+		return redactBody(u, body[:maxTraceBody]) + "...(truncated)"
+	}
+
+	return redactBody(u, body)
+}
+
+// redactBody drops the whole body of sensitive resources and masks json fields whose key
+// looks like a secret in all other bodies
+func redactBody(u *url.URL, body []byte) string {
+	if isSensitiveResource(u) {
+		return fmt.Sprintf("%s (%d bytes)", redacted, len(body))
+	}
+
+	return sensitiveJSONRegEx.ReplaceAllString(string(body), `$1"`+redacted+`"`)
+}
+
+// isSensitiveResource returns true if any segment of the url path names a sensitive resource
+func isSensitiveResource(u *url.URL) bool {
+	if u == nil {
+		return false
+	}
+
+	for _, segment := range strings.Split(strings.ToLower(u.Path), "/") {
+		for _, resource := range sensitiveResources {
+			if segment == resource {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func redactHeaders(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range sensitiveHeaders {
+		values := header.Values(name)
+		for i, value := range values {
+			if scheme, _, found := strings.Cut(value, " "); found && strings.EqualFold(scheme, "Bearer") {
+				values[i] = scheme + " " + redacted
+			} else {
+				values[i] = redacted
+			}
+		}
+	}
+
+	return header
+}
+
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	query := u.Query()
+	changed := false
+	for key := range query {
+		if sensitiveKeyRegEx.MatchString(key) {
+			query.Set(key, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return u.Redacted()
+	}
+
+	redactedURL := *u
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.Redacted()
+}
+
+// har is the root of a HAR 1.2 file, see http://www.softwareishard.com/blog/har-12-spec/
+type har struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator harCreator  `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func newHAREntry(req *http.Request, requestBody []byte, resp *http.Response, started time.Time, elapsed time.Duration) *harEntry {
+	milliseconds := float64(elapsed) / float64(time.Millisecond)
+	entry := &harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            milliseconds,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         redactURL(req.URL),
+			HTTPVersion: req.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(redactHeaders(req.Header)),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Response: harResponse{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: harTimings{Wait: milliseconds},
+	}
+	if entry.Request.HTTPVersion == "" {
+		entry.Request.HTTPVersion = "HTTP/1.1"
+	}
+	if parsed, err := url.Parse(entry.Request.URL); err == nil {
+		for key, values := range parsed.Query() {
+			for _, value := range values {
+				entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: key, Value: value})
+			}
+		}
+	}
+	if len(requestBody) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     truncateBody(req.URL, requestBody),
+		}
+	}
+
+	if resp == nil {
+		entry.Comment = "request failed without a response"
+		return entry
+	}
+
+	entry.Response.Status = resp.StatusCode
+	entry.Response.StatusText = strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprint(resp.StatusCode)))
+	entry.Response.HTTPVersion = resp.Proto
+	entry.Response.Headers = harHeaders(redactHeaders(resp.Header))
+	entry.Response.Content.MimeType = resp.Header.Get("Content-Type")
+	entry.Response.RedirectURL = resp.Header.Get("Location")
+	return entry
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for name, values := range header {
+		for _, value := range values {
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
@@ -0,0 +1,108 @@
+package httputil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTracer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Self","status":{"accessKey":"secret-key","user":"admin"}}`))
+	}))
+	defer server.Close()
+
+	logs := []string{}
+	tracer := NewTracer(func(format string, args ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}, true, true)
+	client := &http.Client{Transport: tracer.Wrap(http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/self?token=abc&watch=true", strings.NewReader(`{"password":"hunter2"}`))
+	assert.NilError(t, err)
+	req.Header.Set("Authorization", "Bearer abc")
+	resp, err := client.Do(req)
+	assert.NilError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NilError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, string(body), `{"kind":"Self","status":{"accessKey":"secret-key","user":"admin"}}`)
+
+	assert.Equal(t, len(logs), 3)
+	for _, line := range logs {
+		assert.Assert(t, !strings.Contains(line, "abc"), line)
+		assert.Assert(t, !strings.Contains(line, "hunter2"), line)
+		assert.Assert(t, !strings.Contains(line, "secret-key"), line)
+	}
+	assert.Assert(t, strings.Contains(logs[0], "200 OK"), logs[0])
+	assert.Assert(t, strings.Contains(logs[2], `"user":"admin"`), logs[2])
+
+	path := filepath.Join(t.TempDir(), "trace.har")
+	assert.NilError(t, tracer.WriteHAR(path))
+	out, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(out), "abc"))
+	assert.Assert(t, !strings.Contains(string(out), "secret-key"))
+
+	recorded := &har{}
+	assert.NilError(t, json.Unmarshal(out, recorded))
+	assert.Equal(t, len(recorded.Log.Entries), 1)
+	assert.Equal(t, recorded.Log.Entries[0].Response.Status, http.StatusOK)
+	assert.Equal(t, recorded.Log.Entries[0].Request.PostData.Text, `{"password":"<redacted>"}`)
+}
+
+func TestTracerSensitiveResources(t *testing.T) {
+	responses := map[string]string{
+		"/api/v1/namespaces/loft/secrets/password":                                                                  `{"kind":"Secret","data":{"password":"aHVudGVyMg=="},"stringData":{"token":"plain-token"}}`,
+		"/kubernetes/management/apis/management.loft.sh/v1/ownedaccesskeys/my-key":                                  `{"kind":"OwnedAccessKey","spec":{"key":"my-access-key-value","user":"admin"}}`,
+		"/kubernetes/management/apis/storage.loft.sh/v1/accesskeys/my-key":                                          `{"kind":"AccessKey","spec":{"key":"my-access-key-value"}}`,
+		"/kubernetes/management/apis/management.loft.sh/v1/namespaces/p-test/projectsecrets/db":                     `{"kind":"ProjectSecret","spec":{"data":{"password":"aHVudGVyMg=="}}}`,
+		"/kubernetes/management/apis/management.loft.sh/v1/namespaces/p-test/virtualclusterinstances/vc/kubeconfig": "users:\n- name: vc\n  user:\n    token: yaml-token-value\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(responses[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	logs := []string{}
+	tracer := NewTracer(func(format string, args ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}, true, true)
+	client := &http.Client{Transport: tracer.Wrap(http.DefaultTransport)}
+	for path := range responses {
+		resp, err := client.Get(server.URL + path)
+		assert.NilError(t, err)
+		_, err = io.ReadAll(resp.Body)
+		assert.NilError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	harPath := filepath.Join(t.TempDir(), "trace.har")
+	assert.NilError(t, tracer.WriteHAR(harPath))
+	out, err := os.ReadFile(harPath)
+	assert.NilError(t, err)
+
+	secrets := []string{"aHVudGVyMg==", "plain-token", "my-access-key-value", "yaml-token-value"}
+	for _, output := range append(logs, string(out)) {
+		for _, secret := range secrets {
+			assert.Assert(t, !strings.Contains(output, secret), output)
+		}
+	}
+	assert.Equal(t, len(logs), 2*len(responses))
+}
+
+func TestNilTracer(t *testing.T) {
+	var tracer *Tracer
+	assert.Equal(t, tracer.Wrap(http.DefaultTransport), http.DefaultTransport)
+	tracer.Trace(&http.Request{}, nil, time.Now(), nil)
+}
@@ -32,11 +32,16 @@ func NewForConfig(c *rest.Config) (Interface, error) {
 		return nil, errors.Wrap(err, "create kiosk client")
 	}
 
+	return NewForClients(kubeClient, loftClient, agentLoftClient), nil
+}
+
+// NewForClients combines existing clientsets, e.g. fake clientsets in tests
+func NewForClients(kubeClient kubernetes.Interface, loftClient loftclient.Interface, agentLoftClient agentloftclient.Interface) Interface {
 	return &client{
 		Interface:       kubeClient,
 		loftClient:      loftClient,
 		agentLoftClient: agentLoftClient,
-	}, nil
+	}
 }
 
 type client struct {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"k8s.io/client-go/pkg/apis/clientauthentication/v1beta1"
 	"k8s.io/client-go/rest"
@@ -12,11 +13,16 @@ import (
 	"k8s.io/client-go/tools/clientcmd/api"
 )
 
+// configLock serializes the read-modify-write of the kube config, so contexts of instances that
+// are changed concurrently aren't lost
+var configLock sync.Mutex
+
 type ContextOptions struct {
 	Name                             string
 	Server                           string
 	CaData                           []byte
 	ConfigPath                       string
+	Profile                          string
 	InsecureSkipTLSVerify            bool
 	DirectClusterEndpointEnabled     bool
 	VirtualClusterAccessPointEnabled bool
@@ -95,6 +101,9 @@ func CurrentContext() (string, error) {
 
 // DeleteContext deletes the context with the given name from the kube config
 func DeleteContext(contextName string) error {
+	configLock.Lock()
+	defer configLock.Unlock()
+
 	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{}).RawConfig()
 	if err != nil {
 		return err
@@ -117,6 +126,9 @@ func DeleteContext(contextName string) error {
 }
 
 func updateKubeConfig(contextName string, cluster *api.Cluster, authInfo *api.AuthInfo, namespaceName string, setActive bool) error {
+	configLock.Lock()
+	defer configLock.Unlock()
+
 	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{}).RawConfig()
 	if err != nil {
 		return err
@@ -260,6 +272,11 @@ func createContext(options ContextOptions) (string, *api.Cluster, *api.AuthInfo,
 				authInfo.Exec.Args = append(authInfo.Exec.Args, "--direct-cluster-endpoint")
 			}
 		}
+
+		// pin the profile, so switching profiles doesn't break existing contexts
+		if options.Profile != "" {
+			authInfo.Exec.Args = append(authInfo.Exec.Args, "--profile", options.Profile)
+		}
 	}
 
 	return contextName, cluster, authInfo, nil
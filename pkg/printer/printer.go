@@ -0,0 +1,367 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	"github.com/loft-sh/log"
+	"github.com/loft-sh/log/table"
+	flag "github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	OutputTable = ""
+	OutputWide  = "wide"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputName  = "name"
+
+	customColumnsPrefix = "custom-columns="
+	goTemplatePrefix    = "go-template="
+)
+
+// Formats lists the supported output formats for flag descriptions and errors
+const Formats = "json, yaml, name, wide, custom-columns=<header>:<json-path>,... or go-template=<template>"
+
+// AddFlag adds the --output flag to the given flags
+func AddFlag(flags *flag.FlagSet, output *string) {
+	flags.StringVarP(output, "output", "o", OutputTable, "Output format. One of: ("+Formats+")")
+}
+
+// Row is a single printed object
+type Row struct {
+	// Object is printed in json, yaml, custom-columns and go-template output
+	Object interface{}
+
+	// Name is printed in name output
+	Name string
+
+	// Values are the table columns of the object
+	Values []string
+
+	// WideValues are the additional table columns of the object in wide output
+	WideValues []string
+}
+
+// Table holds the objects to print
+type Table struct {
+	Header     []string
+	WideHeader []string
+	Rows       []Row
+
+	// Single prints the only row as object instead of as list in json, yaml and go-template output
+	Single bool
+}
+
+// AddRow appends a row to the table
+func (t *Table) AddRow(object interface{}, name string, values []string, wideValues ...string) {
+	t.Rows = append(t.Rows, Row{
+		Object:     object,
+		Name:       name,
+		Values:     values,
+		WideValues: wideValues,
+	})
+}
+
+// Printer prints tables in the configured output format
+type Printer struct {
+	Output string
+
+	// Out is where structured output is written to, tables are printed through the logger
+	Out io.Writer
+	Log log.Logger
+
+	// Scheme is used to fill in the kind of objects that were returned without one
+	Scheme *runtime.Scheme
+
+	columns  []column
+	template *template.Template
+}
+
+type column struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// New validates the output format and creates a new printer for it
+func New(output string, log log.Logger) (*Printer, error) {
+	p := &Printer{
+		Output: output,
+		Out:    os.Stdout,
+		Log:    log,
+		Scheme: DefaultScheme,
+	}
+
+	switch {
+	case output == OutputTable, output == OutputWide, output == OutputJSON, output == OutputYAML, output == OutputName:
+	case strings.HasPrefix(output, customColumnsPrefix):
+		columns, err := parseCustomColumns(strings.TrimPrefix(output, customColumnsPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		p.columns = columns
+	case strings.HasPrefix(output, goTemplatePrefix):
+		tmpl, err := template.New("output").Parse(strings.TrimPrefix(output, goTemplatePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("parse go-template: %w", err)
+		}
+
+		p.template = tmpl
+	default:
+		return nil, fmt.Errorf("unknown output format %s, needs to be one of: %s", output, Formats)
+	}
+
+	return p, nil
+}
+
+// IsTable returns true if the output format prints a human readable table
+func (p *Printer) IsTable() bool {
+	return p.Output == OutputTable || p.Output == OutputWide
+}
+
+// IsStream returns true if the output format prints every object on its own, so single changed
+// objects can be printed via PrintEvent instead of printing the whole table again
+func (p *Printer) IsStream() bool {
+	return !p.IsTable() && p.columns == nil
+}
+
+// PrintEvent prints a single changed object in json, yaml, name or go-template output. Json output
+// is a single line per event, so watches can be consumed as newline delimited json.
+func (p *Printer) PrintEvent(eventType string, row Row) error {
+	if !p.IsStream() {
+		return fmt.Errorf("output format %s cannot print single events", p.Output)
+	} else if p.Output == OutputName {
+		_, err := fmt.Fprintln(p.Out, p.name(row))
+		return err
+	}
+
+	object, err := p.toJSON(row.Object)
+	if err != nil {
+		return err
+	}
+
+	event := map[string]interface{}{
+		"type":   eventType,
+		"object": object,
+	}
+
+	var out []byte
+	switch {
+	case p.Output == OutputJSON:
+		out, err = json.Marshal(event)
+		out = append(out, '\n')
+	case p.Output == OutputYAML:
+		out, err = yaml.Marshal(event)
+		out = append([]byte("---\n"), out...)
+	case p.template != nil:
+		buffer := &bytes.Buffer{}
+		err = p.template.Execute(buffer, object)
+		out = buffer.Bytes()
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Out.Write(out)
+	return err
+}
+
+// Print prints the table in the configured output format
+func (p *Printer) Print(t *Table) error {
+	switch {
+	case p.Output == OutputTable:
+		values := make([][]string, 0, len(t.Rows))
+		for _, row := range t.Rows {
+			values = append(values, row.Values)
+		}
+
+		table.PrintTable(p.Log, t.Header, values)
+		return nil
+	case p.Output == OutputWide:
+		values := make([][]string, 0, len(t.Rows))
+		for _, row := range t.Rows {
+			values = append(values, append(append([]string{}, row.Values...), row.WideValues...))
+		}
+
+		table.PrintTable(p.Log, append(append([]string{}, t.Header...), t.WideHeader...), values)
+		return nil
+	case p.Output == OutputName:
+		for _, row := range t.Rows {
+			_, err := fmt.Fprintln(p.Out, p.name(row))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case p.columns != nil:
+		return p.printCustomColumns(t)
+	}
+
+	data, err := p.data(t)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	switch {
+	case p.Output == OutputJSON:
+		out, err = json.MarshalIndent(data, "", "  ")
+		out = append(out, '\n')
+	case p.Output == OutputYAML:
+		out, err = yaml.Marshal(data)
+	case p.template != nil:
+		buffer := &bytes.Buffer{}
+		err = p.template.Execute(buffer, data)
+		out = buffer.Bytes()
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Out.Write(out)
+	return err
+}
+
+// data converts the objects of the table into generic json values, so json paths and
+// templates see the same field names as the json output
+func (p *Printer) data(t *Table) (interface{}, error) {
+	items := make([]interface{}, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		item, err := p.toJSON(row.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	if t.Single && len(items) == 1 {
+		return items[0], nil
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}, nil
+}
+
+func (p *Printer) toJSON(object interface{}) (interface{}, error) {
+	if obj, ok := object.(runtime.Object); ok && p.Scheme != nil && obj.GetObjectKind().GroupVersionKind().Empty() {
+		gvks, _, err := p.Scheme.ObjectKinds(obj)
+		if err == nil && len(gvks) > 0 {
+			obj = obj.DeepCopyObject()
+			obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+			object = obj
+		}
+	}
+
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	var out interface{}
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// name returns kind/name for objects with a known kind and the plain name otherwise
+func (p *Printer) name(row Row) string {
+	obj, ok := row.Object.(runtime.Object)
+	if !ok {
+		return row.Name
+	}
+
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" && p.Scheme != nil {
+		gvks, _, err := p.Scheme.ObjectKinds(obj)
+		if err == nil && len(gvks) > 0 {
+			kind = gvks[0].Kind
+		}
+	}
+
+	name := row.Name
+	if accessor, err := meta.Accessor(obj); err == nil && accessor.GetName() != "" {
+		name = accessor.GetName()
+	}
+	if kind == "" {
+		return name
+	}
+
+	return strings.ToLower(kind) + "/" + name
+}
+
+func (p *Printer) printCustomColumns(t *Table) error {
+	header := make([]string, 0, len(p.columns))
+	for _, column := range p.columns {
+		header = append(header, column.header)
+	}
+
+	values := make([][]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		data, err := p.toJSON(row.Object)
+		if err != nil {
+			return err
+		}
+
+		rowValues := make([]string, 0, len(p.columns))
+		for _, column := range p.columns {
+			buffer := &bytes.Buffer{}
+			err = column.path.Execute(buffer, data)
+			if err != nil {
+				return fmt.Errorf("column %s: %w", column.header, err)
+			}
+
+			value := buffer.String()
+			if value == "" {
+				value = "<none>"
+			}
+			rowValues = append(rowValues, value)
+		}
+		values = append(values, rowValues)
+	}
+
+	table.PrintTable(p.Log, header, values)
+	return nil
+}
+
+// parseCustomColumns parses a spec such as NAME:.metadata.name,PHASE:.status.phase
+func parseCustomColumns(spec string) ([]column, error) {
+	var columns []column
+	for _, part := range strings.Split(spec, ",") {
+		header, path, found := strings.Cut(part, ":")
+		if !found || header == "" || path == "" {
+			return nil, fmt.Errorf("invalid custom column %q, needs to be in the form <header>:<json-path>", part)
+		}
+
+		if !strings.HasPrefix(path, "{") {
+			path = "{" + path + "}"
+		}
+
+		parser := jsonpath.New(header).AllowMissingKeys(true)
+		err := parser.Parse(path)
+		if err != nil {
+			return nil, fmt.Errorf("parse custom column %s: %w", header, err)
+		}
+
+		columns = append(columns, column{header: header, path: parser})
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,85 @@
+package printer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/loft-sh/log"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testTable() *Table {
+	t := &Table{Header: []string{"Name"}, WideHeader: []string{"Phase"}}
+	for _, name := range []string{"a", "b"} {
+		t.AddRow(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		}, name, []string{name}, string(corev1.NamespaceActive))
+	}
+
+	return t
+}
+
+func TestPrinter(t *testing.T) {
+	tests := map[string]string{
+		OutputName: "namespace/a\nnamespace/b\n",
+		OutputJSON: `"kind": "Namespace"`,
+		OutputYAML: "kind: List",
+		"go-template={{range .items}}{{.metadata.name}}:{{.status.phase}} {{end}}": "a:Active b:Active ",
+	}
+	for output, expected := range tests {
+		printer, err := New(output, log.Discard)
+		assert.NilError(t, err)
+
+		out := &bytes.Buffer{}
+		printer.Out = out
+		assert.NilError(t, printer.Print(testTable()))
+		assert.Assert(t, bytes.Contains(out.Bytes(), []byte(expected)), "%s: %s", output, out.String())
+	}
+
+	_, err := New("xml", log.Discard)
+	assert.ErrorContains(t, err, "unknown output format xml")
+	_, err = New("custom-columns=NAME", log.Discard)
+	assert.ErrorContains(t, err, "invalid custom column")
+}
+
+func TestPrinterSingle(t *testing.T) {
+	printer, err := New("go-template={{.metadata.name}}", log.Discard)
+	assert.NilError(t, err)
+
+	table := testTable()
+	table.Rows = table.Rows[:1]
+	table.Single = true
+	out := &bytes.Buffer{}
+	printer.Out = out
+	assert.NilError(t, printer.Print(table))
+	assert.Equal(t, out.String(), "a")
+}
+
+func TestParseCustomColumns(t *testing.T) {
+	columns, err := parseCustomColumns("NAME:.metadata.name,PHASE:{.status.phase}")
+	assert.NilError(t, err)
+	assert.Equal(t, len(columns), 2)
+	assert.Equal(t, columns[1].header, "PHASE")
+}
+
+func TestPrintEvent(t *testing.T) {
+	printer, err := New(OutputJSON, log.Discard)
+	assert.NilError(t, err)
+
+	out := &bytes.Buffer{}
+	printer.Out = out
+	for _, row := range testTable().Rows {
+		assert.NilError(t, printer.PrintEvent("ADDED", row))
+	}
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Equal(t, len(lines), 2)
+	assert.Assert(t, bytes.HasPrefix(lines[0], []byte(`{"object":{"apiVersion":"v1","kind":"Namespace"`)), string(lines[0]))
+	assert.Assert(t, bytes.HasSuffix(lines[1], []byte(`"type":"ADDED"}`)), string(lines[1]))
+
+	printer, err = New(OutputWide, log.Discard)
+	assert.NilError(t, err)
+	assert.ErrorContains(t, printer.PrintEvent("ADDED", testTable().Rows[0]), "cannot print single events")
+}
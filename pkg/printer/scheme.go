@@ -0,0 +1,18 @@
+package printer
+
+import (
+	agentscheme "github.com/loft-sh/agentapi/v4/pkg/client/loft/clientset_generated/clientset/scheme"
+	loftscheme "github.com/loft-sh/api/v4/pkg/client/clientset_generated/clientset/scheme"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// DefaultScheme knows the types of the management api, the agent api and kubernetes
+var DefaultScheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(DefaultScheme))
+	utilruntime.Must(loftscheme.AddToScheme(DefaultScheme))
+	utilruntime.Must(agentscheme.AddToScheme(DefaultScheme))
+}
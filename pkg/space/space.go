@@ -29,7 +29,7 @@ func WaitForSpaceInstance(ctx context.Context, managementClient kube.Interface,
 	}
 
 	if spaceInstance.Status.Phase == storagev1.InstanceSleeping {
-		log.Info("Wait until space wakes up")
+		log.Infof("Wait until space %s wakes up", name)
 		defer log.Donef("Successfully woken up space %s", name)
 		err := wakeup(ctx, managementClient, spaceInstance)
 		if err != nil {
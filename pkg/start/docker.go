@@ -124,6 +124,7 @@ func (l *LoftStarter) successDocker(ctx context.Context, containerID string) err
 
 	// wait for domain to become reachable
 	l.Log.Infof(product.Replace("Wait for Loft to become available at %s..."), host)
+	tlsConfig := l.loftTLSConfig(host)
 	err = wait.PollUntilContextTimeout(ctx, time.Second, time.Minute*10, true, func(ctx context.Context) (bool, error) {
 		containerDetails, err := l.inspectContainer(ctx, containerID)
 		if err != nil {
@@ -133,7 +134,7 @@ func (l *LoftStarter) successDocker(ctx context.Context, containerID string) err
 			return false, fmt.Errorf("container failed (status: %s):\n %s", containerDetails.State.Status, logs)
 		}
 
-		return clihelper.IsLoftReachable(ctx, host)
+		return clihelper.IsLoftReachable(ctx, host, tlsConfig)
 	})
 	if err != nil {
 		return fmt.Errorf(product.Replace("error waiting for loft: %v%w"), err)
@@ -68,7 +68,7 @@ func (l *LoftStarter) success(ctx context.Context) error {
 	}
 
 	// check if loft is reachable
-	reachable, err := clihelper.IsLoftReachable(ctx, host)
+	reachable, err := clihelper.IsLoftReachable(ctx, host, l.loftTLSConfig(host))
 	if !reachable || err != nil {
 		const (
 			YesOption = "Yes"
@@ -182,8 +182,31 @@ func (l *LoftStarter) isLoggedIn(url string) bool {
 	return err == nil && strings.TrimPrefix(strings.TrimSuffix(c.Config().Host, "/"), "https://") == strings.TrimSuffix(url, "/")
 }
 
+// loftTLSConfig returns the tls config to verify loft with, if the cli is logged
+// into the given host and a certificate authority was configured for it
+func (l *LoftStarter) loftTLSConfig(host string) *tls.Config {
+	c, err := client.NewClientFromPath(l.Config)
+	if err != nil || strings.TrimPrefix(strings.TrimSuffix(c.Config().Host, "/"), "https://") != strings.TrimSuffix(host, "/") {
+		return nil
+	}
+
+	tlsOptions := c.Config().TLSOptions()
+	if !tlsOptions.HasCertificateAuthority() {
+		return nil
+	}
+
+	tlsConfig, err := tlsOptions.TLSConfig()
+	if err != nil {
+		l.Log.Debugf("Error loading certificate authority: %v", err)
+		return nil
+	}
+
+	return tlsConfig
+}
+
 func (l *LoftStarter) successRemote(ctx context.Context, host string) error {
-	ready, err := clihelper.IsLoftReachable(ctx, host)
+	tlsConfig := l.loftTLSConfig(host)
+	ready, err := clihelper.IsLoftReachable(ctx, host, tlsConfig)
 	if err != nil {
 		return err
 	} else if ready {
@@ -196,7 +219,7 @@ func (l *LoftStarter) successRemote(ctx context.Context, host string) error {
 
 	l.Log.Info("Waiting for you to configure DNS, so loft can be reached on https://" + host)
 	err = wait.PollUntilContextTimeout(ctx, 5*time.Second, config.Timeout(), true, func(ctx context.Context) (done bool, err error) {
-		return clihelper.IsLoftReachable(ctx, host)
+		return clihelper.IsLoftReachable(ctx, host, tlsConfig)
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,101 @@
+package tokencache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/pkg/apis/clientauthentication/v1beta1"
+)
+
+// MinValidity is how long a cached credential has to be valid at least to be used
+const MinValidity = time.Minute
+
+// Cache stores exec credentials on disk, so kubectl doesn't need to refresh them on every invocation
+type Cache struct {
+	// Dir is the folder the credentials are stored in
+	Dir string
+}
+
+// New returns a cache that stores its credentials in the given folder
+func New(dir string) *Cache {
+	return &Cache{Dir: dir}
+}
+
+// Key builds a cache key from the given parts, e.g. the config, profile and kube context. Secrets
+// such as the access key can be part of the key, as only their hash is stored.
+func Key(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:16])
+}
+
+// Get returns the cached credential for the key if it's still valid for at least MinValidity
+func (c *Cache) Get(key string) (*v1beta1.ExecCredential, bool) {
+	out, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	credential := &v1beta1.ExecCredential{}
+	err = json.Unmarshal(out, credential)
+	if err != nil || credential.Status == nil || credential.Status.ExpirationTimestamp == nil {
+		return nil, false
+	} else if time.Until(credential.Status.ExpirationTimestamp.Time) < MinValidity {
+		_ = os.Remove(c.path(key))
+		return nil, false
+	}
+
+	return credential, true
+}
+
+// Set caches the credential for the key. Credentials without expiration are not cached.
+func (c *Cache) Set(key string, credential *v1beta1.ExecCredential) error {
+	if credential.Status == nil || credential.Status.ExpirationTimestamp == nil {
+		return nil
+	}
+
+	out, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(c.Dir, 0o700)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so concurrent kubectl calls never read a partial credential
+	file, err := os.CreateTemp(c.Dir, "."+key+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(out)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(file.Name(), 0o600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), c.path(key))
+}
+
+// Clear removes all cached credentials
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
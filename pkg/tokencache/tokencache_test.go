@@ -0,0 +1,44 @@
+package tokencache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/pkg/apis/clientauthentication/v1beta1"
+)
+
+func TestCache(t *testing.T) {
+	cache := New(filepath.Join(t.TempDir(), "tokens"))
+	key := Key("config.json", "default", "loft_vcluster_a_b")
+	assert.Assert(t, key != Key("config.json", "other", "loft_vcluster_a_b"))
+
+	_, ok := cache.Get(key)
+	assert.Assert(t, !ok)
+
+	// credentials without expiration are never cached
+	assert.NilError(t, cache.Set(key, &v1beta1.ExecCredential{Status: &v1beta1.ExecCredentialStatus{Token: "token"}}))
+	_, ok = cache.Get(key)
+	assert.Assert(t, !ok)
+
+	expiration := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+	assert.NilError(t, cache.Set(key, &v1beta1.ExecCredential{Status: &v1beta1.ExecCredentialStatus{Token: "token", ExpirationTimestamp: &expiration}}))
+	credential, ok := cache.Get(key)
+	assert.Assert(t, ok)
+	assert.Equal(t, credential.Status.Token, "token")
+
+	stat, err := os.Stat(cache.path(key))
+	assert.NilError(t, err)
+	assert.Equal(t, stat.Mode().Perm(), os.FileMode(0o600))
+
+	// credentials that expire soon are dropped
+	expiration = metav1.NewTime(time.Now().Add(MinValidity / 2))
+	assert.NilError(t, cache.Set(key, &v1beta1.ExecCredential{Status: &v1beta1.ExecCredentialStatus{Token: "token", ExpirationTimestamp: &expiration}}))
+	_, ok = cache.Get(key)
+	assert.Assert(t, !ok)
+	_, err = os.Stat(cache.path(key))
+	assert.Assert(t, os.IsNotExist(err))
+}
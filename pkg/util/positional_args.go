@@ -14,11 +14,19 @@ var (
 	VClusterNameOnlyUseLine string
 
 	VClusterNameOnlyValidator cobra.PositionalArgs
+
+	SpaceNamesUseLine   string
+	SpaceNamesValidator cobra.PositionalArgs
+
+	VClusterNamesUseLine   string
+	VClusterNamesValidator cobra.PositionalArgs
 )
 
 func init() {
 	SpaceNameOnlyUseLine, SpaceNameOnlyValidator = NamedPositionalArgsValidator(true, true, "SPACE_NAME")
 	VClusterNameOnlyUseLine, VClusterNameOnlyValidator = NamedPositionalArgsValidator(true, true, "VCLUSTER_NAME")
+	SpaceNamesUseLine, SpaceNamesValidator = NamedPositionalArgsValidator(false, false, "[SPACE_NAME...]")
+	VClusterNamesUseLine, VClusterNamesValidator = NamedPositionalArgsValidator(false, false, "[VCLUSTER_NAME...]")
 }
 
 // NamedPositionalArgsValidator returns a cobra.PositionalArgs that returns a helpful
@@ -59,7 +59,7 @@ func WaitForVirtualClusterInstance(ctx context.Context, managementClient kube.In
 	}
 
 	if virtualClusterInstance.Status.Phase == storagev1.InstanceSleeping {
-		log.Info("Wait until vcluster wakes up")
+		log.Infof("Wait until vcluster %s wakes up", name)
 		defer log.Donef("Successfully woken up vcluster %s", name)
 		err := wakeup(ctx, managementClient, virtualClusterInstance)
 		if err != nil {